@@ -0,0 +1,7 @@
+data "looker_datagroups" "thelook" {
+  model_name = "thelook"
+}
+
+output "failing_datagroups" {
+  value = [for dg in data.looker_datagroups.thelook.datagroups : dg.name if dg.trigger_error != ""]
+}
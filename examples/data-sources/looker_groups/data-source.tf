@@ -0,0 +1,12 @@
+data "looker_groups" "saml" {
+  name_regex = "^saml_"
+}
+
+data "looker_role" "viewer" {
+  name = "Viewer"
+}
+
+resource "looker_role_groups" "viewer" {
+  role_id   = data.looker_role.viewer.id
+  group_ids = data.looker_groups.saml.ids
+}
@@ -0,0 +1,8 @@
+data "looker_model_sets" "lemon" {
+  name_prefix = "tf_"
+  name_regex  = "lemon"
+}
+
+output "lemon_models" {
+  value = flatten(data.looker_model_sets.lemon.model_sets[*].models)
+}
@@ -0,0 +1,5 @@
+data "looker_permission_sets" "all" {}
+
+output "custom_permission_sets" {
+  value = [for ps in data.looker_permission_sets.all.permission_sets : ps.name if !ps.built_in]
+}
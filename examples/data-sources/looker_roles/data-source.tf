@@ -0,0 +1,7 @@
+data "looker_roles" "terraform" {
+  name_prefix = "tf_"
+}
+
+output "terraform_roles" {
+  value = { for r in data.looker_roles.terraform.roles : r.name => r.id }
+}
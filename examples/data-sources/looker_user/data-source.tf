@@ -0,0 +1,12 @@
+data "looker_user" "jane" {
+  email = "jane.doe@mydomain.com"
+}
+
+data "looker_role" "developer" {
+  name = "Developer"
+}
+
+resource "looker_user_roles" "jane" {
+  user_id  = data.looker_user.jane.id
+  role_ids = [data.looker_role.developer.id]
+}
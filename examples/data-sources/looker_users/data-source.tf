@@ -0,0 +1,15 @@
+data "looker_users" "mydomain" {
+  email       = "%@mydomain.com"
+  is_disabled = false
+}
+
+data "looker_group" "mydomain" {
+  name = "My Domain"
+}
+
+resource "looker_group_user" "mydomain" {
+  for_each = toset(data.looker_users.mydomain.ids)
+
+  group_id = data.looker_group.mydomain.id
+  user_id  = each.value
+}
@@ -0,0 +1,3 @@
+# A `looker_alert` resource can be imported using the following syntax:
+
+terraform import looker_alert.orders_target {{alert_id}}
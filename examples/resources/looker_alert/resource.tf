@@ -0,0 +1,27 @@
+resource "looker_alert" "orders_target" {
+  dashboard_element_id = "46"
+  comparison_type      = "LESS_THAN"
+  threshold            = 1000
+  cron                 = "0 7 * * 1-5"
+  description          = "Daily orders below target"
+  is_public            = true
+
+  # the alert is owned by the service account instead of the analyst who created it
+  owner_id = data.looker_user.reporting.id
+
+  field {
+    name  = "orders.count"
+    title = "Orders Count"
+  }
+
+  destination {
+    type          = "EMAIL"
+    email_address = "analytics@example.com"
+  }
+
+  destination {
+    type                        = "ACTION_HUB"
+    action_hub_integration_id   = "1::slack_app"
+    action_hub_form_params_json = jsonencode({ channel = "#orders" })
+  }
+}
@@ -0,0 +1,4 @@
+# A `looker_connection` resource can be imported using the name of the connection. The `password` and `certificate`
+# cannot be read from the Looker API and are not imported.
+
+terraform import looker_connection.warehouse {{connection_name}}
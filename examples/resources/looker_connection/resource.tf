@@ -0,0 +1,24 @@
+resource "looker_connection" "warehouse" {
+  name            = "warehouse"
+  dialect         = "snowflake"
+  host            = "my-account.snowflakecomputing.com"
+  database        = "ANALYTICS"
+  schema          = "PUBLIC"
+  username        = "LOOKER"
+  password        = var.snowflake_password
+  ssl             = true
+  tmp_db_name     = "LOOKER_SCRATCH"
+  max_connections = 20
+  test_on_apply   = true
+}
+
+resource "looker_connection" "bigquery" {
+  name        = "bigquery"
+  dialect     = "bigquery_standard_sql"
+  host        = "my-gcp-project"
+  database    = "analytics"
+  username    = "looker@my-gcp-project.iam.gserviceaccount.com"
+  certificate = filebase64("looker-service-account.json")
+  file_type   = ".json"
+  tmp_db_name = "looker_scratch"
+}
@@ -0,0 +1,3 @@
+# A `looker_dashboard` resource can be imported using the following syntax. An imported dashboard is read as JSON:
+
+terraform import looker_dashboard.welcome {{dashboard_id}}
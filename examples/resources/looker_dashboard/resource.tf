@@ -0,0 +1,40 @@
+# a dashboard created from the LookML downloaded from another instance
+resource "looker_dashboard" "orders" {
+  folder_id = looker_folder.marketing.id
+  lookml    = file("${path.module}/dashboards/orders.dashboard.lookml")
+}
+
+# a dashboard created from a JSON definition
+resource "looker_dashboard" "welcome" {
+  folder_id = looker_folder.marketing.id
+  json = jsonencode({
+    title       = "Welcome"
+    description = "Start here"
+    dashboard_filters = [
+      {
+        name      = "Status"
+        title     = "Status"
+        type      = "field_filter"
+        model     = "thelook"
+        explore   = "orders"
+        dimension = "orders.status"
+      }
+    ]
+    dashboard_elements = [
+      {
+        type       = "text"
+        title_text = "Welcome"
+        body_text  = "Orders by status"
+      },
+      {
+        type       = "looker_column"
+        title_text = "Orders"
+        query = {
+          model  = "thelook"
+          view   = "orders"
+          fields = ["orders.status", "orders.count"]
+        }
+      }
+    ]
+  })
+}
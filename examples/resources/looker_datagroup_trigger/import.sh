@@ -0,0 +1,3 @@
+# A `looker_datagroup_trigger` resource can be imported using the following syntax:
+
+terraform import looker_datagroup_trigger.orders {{datagroup_id}}
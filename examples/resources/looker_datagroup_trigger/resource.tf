@@ -0,0 +1,7 @@
+# rebuilds the persistent derived tables of the orders datagroup after each run of the ETL job
+resource "looker_datagroup_trigger" "orders" {
+  datagroup_id = one([for dg in data.looker_datagroups.thelook.datagroups : dg.id if dg.name == "orders_datagroup"])
+  triggers = {
+    etl_run_id = var.etl_run_id
+  }
+}
@@ -0,0 +1,3 @@
+# A `looker_folder` resource can be imported using the following syntax:
+
+terraform import looker_folder.marketing {{folder_id}}
@@ -0,0 +1,9 @@
+resource "looker_folder" "marketing" {
+  name      = "Marketing"
+  parent_id = "1" # the id of the Shared folder
+}
+
+resource "looker_folder" "campaigns" {
+  name      = "Campaigns"
+  parent_id = looker_folder.marketing.id
+}
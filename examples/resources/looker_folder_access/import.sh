@@ -0,0 +1,3 @@
+# A `looker_folder_access` resource can be imported using the id of the folder. All access currently set on the folder is imported.
+
+terraform import looker_folder_access.finance {{folder_id}}
@@ -0,0 +1,27 @@
+resource "looker_folder" "finance" {
+  name      = "Finance"
+  parent_id = "1"
+}
+
+resource "looker_group" "finance_analysts" {
+  name = "Finance Analysts"
+}
+
+resource "looker_group" "finance_viewers" {
+  name = "Finance Viewers"
+}
+
+resource "looker_folder_access" "finance" {
+  folder_id     = looker_folder.finance.id
+  authoritative = true
+
+  access {
+    group_id        = looker_group.finance_analysts.id
+    permission_type = "edit"
+  }
+
+  access {
+    group_id        = looker_group.finance_viewers.id
+    permission_type = "view"
+  }
+}
@@ -0,0 +1,4 @@
+# A `looker_ldap_config` has only one configuration for a Looker instance, so its id is always `ldap`. The argument passed to import the config is ignored.
+# See the below example:
+
+terraform import looker_ldap_config.ldap ldap
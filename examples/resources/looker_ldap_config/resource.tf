@@ -0,0 +1,40 @@
+data "looker_role" "viewer" {
+  name = "Viewer"
+}
+
+data "looker_group" "all_users" {
+  name = "All Users"
+}
+
+resource "looker_ldap_config" "ldap" {
+  enabled         = true
+  connection_host = "ldap.mydomain.com"
+  connection_port = "636"
+  connection_tls  = true
+
+  auth_username     = "cn=looker,ou=services,dc=mydomain,dc=com"
+  auth_password     = var.ldap_bind_password
+  user_bind_base_dn = "ou=people,dc=mydomain,dc=com"
+  groups_base_dn    = "ou=groups,dc=mydomain,dc=com"
+
+  user_attribute_map_email      = "mail"
+  user_attribute_map_first_name = "givenName"
+  user_attribute_map_last_name  = "sn"
+  merge_new_users_by_email      = true
+
+  default_new_user_role_ids  = [data.looker_role.viewer.id]
+  default_new_user_group_ids = [data.looker_group.all_users.id]
+
+  set_roles_from_groups = true
+
+  groups_with_role_ids {
+    name              = "cn=looker-viewers,ou=groups,dc=mydomain,dc=com"
+    looker_group_name = "Viewers"
+    role_ids          = [data.looker_role.viewer.id]
+  }
+
+  validate {
+    test_ldap_user     = "tina"
+    test_ldap_password = var.ldap_test_password
+  }
+}
@@ -0,0 +1,3 @@
+# A `looker_look` resource can be imported using the following syntax:
+
+terraform import looker_look.orders_by_status {{look_id}}
@@ -0,0 +1,16 @@
+resource "looker_look" "orders_by_status" {
+  title       = "Orders by status"
+  description = "Orders of the last week by status"
+  folder_id   = looker_folder.marketing.id
+
+  query {
+    model  = "thelook"
+    view   = "orders"
+    fields = ["orders.status", "orders.count"]
+    filters = {
+      "orders.created_date" = "7 days"
+    }
+    sorts = ["orders.count desc"]
+    limit = 100
+  }
+}
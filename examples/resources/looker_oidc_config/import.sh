@@ -0,0 +1,4 @@
+# A `looker_oidc_config` has only one configuration for a Looker instance, so its id is always `oidc`. The argument passed to import the config is ignored.
+# See the below example:
+
+terraform import looker_oidc_config.okta oidc
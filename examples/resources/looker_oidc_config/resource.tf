@@ -0,0 +1,35 @@
+data "looker_role" "viewer" {
+  name = "Viewer"
+}
+
+data "looker_group" "all_users" {
+  name = "All Users"
+}
+
+resource "looker_oidc_config" "okta" {
+  enabled                = true
+  issuer                 = "https://mydomain.okta.com"
+  authorization_endpoint = "https://mydomain.okta.com/oauth2/v1/authorize"
+  token_endpoint         = "https://mydomain.okta.com/oauth2/v1/token"
+  userinfo_endpoint      = "https://mydomain.okta.com/oauth2/v1/userinfo"
+  identifier             = "0oa1b2c3d4e5f6g7h8i9"
+  secret                 = var.okta_client_secret
+  scopes                 = ["openid", "email", "profile", "groups"]
+
+  user_attribute_map_email      = "email"
+  user_attribute_map_first_name = "given_name"
+  user_attribute_map_last_name  = "family_name"
+  new_user_migration_types      = ["email"]
+
+  default_new_user_role_ids  = [data.looker_role.viewer.id]
+  default_new_user_group_ids = [data.looker_group.all_users.id]
+
+  groups_attribute      = "groups"
+  set_roles_from_groups = true
+
+  groups_with_role_ids {
+    name              = "Looker Viewers"
+    looker_group_name = "Viewers"
+    role_ids          = [data.looker_role.viewer.id]
+  }
+}
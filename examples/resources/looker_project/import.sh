@@ -0,0 +1,3 @@
+# A `looker_project` resource can be imported using the following syntax:
+
+terraform import looker_project.analytics {{project_name}}
@@ -0,0 +1,6 @@
+resource "looker_project" "analytics" {
+  name                = "analytics"
+  git_remote_url      = "git@github.com:my-org/looker-analytics.git"
+  pull_request_mode   = "required"
+  validation_required = true
+}
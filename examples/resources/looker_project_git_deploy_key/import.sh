@@ -0,0 +1,3 @@
+# A `looker_project_git_deploy_key` resource can be imported using the following syntax:
+
+terraform import looker_project_git_deploy_key.analytics {{project_name}}
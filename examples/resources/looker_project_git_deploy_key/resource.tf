@@ -0,0 +1,16 @@
+resource "looker_project" "analytics" {
+  name           = "analytics"
+  git_remote_url = "git@github.com:my-org/looker-analytics.git"
+}
+
+resource "looker_project_git_deploy_key" "analytics" {
+  project_id = looker_project.analytics.id
+}
+
+# register the deploy key with the git host, eg. using the github provider
+resource "github_repository_deploy_key" "looker" {
+  title      = "Looker"
+  repository = "looker-analytics"
+  key        = looker_project_git_deploy_key.analytics.public_key
+  read_only  = false
+}
@@ -1,4 +1,4 @@
-# A `looker_saml_config` has only one configuration for a Looker instance. Therefore the config has no specific `id` and argument passed to import the config can be anything. 
+# A `looker_saml_config` has only one configuration for a Looker instance, so its id is always `saml`. The argument passed to import the config is ignored.
 # See the below example:
 
-terraform import looker_saml_config.tina test
+terraform import looker_saml_config.saml saml
@@ -6,11 +6,15 @@ data "looker_group" "all_users" {
   name = "All Users"
 }
 
+data "looker_idp_metadata" "idp" {
+  idp_metadata_url = "https://mydomain.com/samlp/metadata/123456"
+}
+
 resource "looker_saml_config" "saml" {
   enabled    = true
-  idp_cert   = "mycert"
-  idp_url    = "https://mydomain.com/samlp/metadata/123456"
-  idp_issuer = "urn:mydomain.com"
+  idp_cert   = data.looker_idp_metadata.idp.idp_cert
+  idp_url    = data.looker_idp_metadata.idp.idp_url
+  idp_issuer = data.looker_idp_metadata.idp.idp_issuer
 
   user_attribute_map_email      = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"
   user_attribute_map_first_name = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname"
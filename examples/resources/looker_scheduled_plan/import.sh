@@ -0,0 +1,3 @@
+# A `looker_scheduled_plan` resource can be imported using the following syntax. The secret parameters of destinations are not imported:
+
+terraform import looker_scheduled_plan.daily_orders {{scheduled_plan_id}}
@@ -0,0 +1,43 @@
+resource "looker_scheduled_plan" "daily_orders" {
+  name         = "Daily orders"
+  dashboard_id = looker_dashboard.welcome.id
+  crontab      = "0 7 * * 1-5"
+  timezone     = "Europe/London"
+
+  filters = {
+    Status = "complete"
+  }
+
+  destination {
+    type    = "email"
+    format  = "wysiwyg_pdf"
+    address = "analytics@example.com"
+    message = "The orders of yesterday"
+  }
+
+  destination {
+    type    = "s3"
+    format  = "csv_zip"
+    address = "s3://reports/orders"
+    parameters = {
+      region        = "eu-west-2"
+      access_key_id = var.reports_access_key_id
+    }
+    secret_parameters = {
+      secret_access_key = var.reports_secret_access_key
+    }
+  }
+}
+
+# a Look delivered to a webhook when the orders datagroup is triggered
+resource "looker_scheduled_plan" "orders_webhook" {
+  name      = "Orders webhook"
+  look_id   = looker_look.orders_by_status.id
+  datagroup = "orders_datagroup"
+
+  destination {
+    type    = "webhook"
+    format  = "json_detail"
+    address = "https://hooks.example.com/looker/orders"
+  }
+}
@@ -29,6 +29,14 @@ func P[T any](v T) *T {
 	return &v
 }
 
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
 func SchemaSetToSliceString(set *schema.Set) ([]string, error) {
 	slice := make([]string, set.Len())
 	for i, v := range set.List() {
@@ -41,3 +49,16 @@ func SchemaSetToSliceString(set *schema.Set) ([]string, error) {
 
 	return slice, nil
 }
+
+func SchemaListToSliceString(list []interface{}) ([]string, error) {
+	slice := make([]string, len(list))
+	for i, v := range list {
+		str, ok := v.(string)
+		if !ok {
+			return nil, errors.New("list contains a non-string element")
+		}
+		slice[i] = str
+	}
+
+	return slice, nil
+}
@@ -1,6 +1,7 @@
 package slice
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -77,6 +78,13 @@ func StartsWithString(prefix string) Predicate[string] {
 	}
 }
 
+// MatchesRegexp returns true if the predicate string matches the provided regular expression
+func MatchesRegexp(re *regexp.Regexp) Predicate[string] {
+	return func(elem string) bool {
+		return re.MatchString(elem)
+	}
+}
+
 // Filter takes a generic slice and a predicate func and returns a slice of all elements in the original slice that satisfy the predicate
 func Filter[T constraints.Ordered](slice []T, predicateFunc Predicate[T]) []T {
 	filtered := make([]T, 0)
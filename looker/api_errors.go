@@ -0,0 +1,113 @@
+package looker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+	"github.com/resolutionlife/terraform-provider-looker/internal/slice"
+)
+
+// apiErrorPattern matches the errors returned by the Looker SDK for a response with an error status, eg.
+// `response error. status=422 Unprocessable Entity. error={"message":"Validation Failed","errors":[...]}`.
+var apiErrorPattern = regexp.MustCompile(`(?s)response error\. status=(\d{3})[^.]*\. error=(.*)$`)
+
+// apiError is an error response of the Looker API.
+type apiError struct {
+	status int
+	body   sdk.ValidationError
+}
+
+// parseAPIError returns the error response of the Looker API which caused err, or false if err was not caused by an error response.
+func parseAPIError(err error) (apiError, bool) {
+	m := apiErrorPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return apiError{}, false
+	}
+
+	status, atoiErr := strconv.Atoi(m[1])
+	if atoiErr != nil {
+		return apiError{}, false
+	}
+
+	// the sdk appends sdk.ErrNotFound to the body of a 404 response
+	raw := strings.TrimSuffix(m[2], ": "+sdk.ErrNotFound.Error())
+
+	var body sdk.ValidationError
+	if jsonErr := json.Unmarshal([]byte(raw), &body); jsonErr != nil || body.Message == "" {
+		// the body of some error responses is not json, eg. the responses of a proxy, so it is reported as it is
+		body = sdk.ValidationError{Message: strings.TrimSpace(raw)}
+	}
+
+	return apiError{status: status, body: body}, true
+}
+
+// detail returns the message of the error response followed by hint, and a link to the documentation of the error if there is one.
+func (e apiError) detail(hint string) string {
+	detail := fmt.Sprintf("Looker returned %d %s: %s", e.status, http.StatusText(e.status), e.body.Message)
+	if hint != "" {
+		detail += "\n\n" + hint
+	}
+	if e.body.DocumentationUrl != "" {
+		detail += "\n\nSee " + e.body.DocumentationUrl
+	}
+	return detail
+}
+
+// apiDiags turns an error returned by the Looker API into diagnostics, which start with summary. The validation errors of a 422
+// response are reported on the attribute of the same name as the field of the error, if it is one of attrs.
+func apiDiags(err error, summary string, attrs ...string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	apiErr, ok := parseAPIError(err)
+	if !ok {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: err.Error()}}
+	}
+
+	switch apiErr.status {
+	case http.StatusNotFound:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary + ": not found",
+			Detail:   apiErr.detail("The object does not exist, or the API user does not have the permissions to see it."),
+		}}
+	case http.StatusConflict:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary + ": already exists",
+			Detail:   apiErr.detail("An object with the same unique attributes, eg. the same name, already exists in Looker. Import the existing object into the terraform state, or change its unique attributes."),
+		}}
+	case http.StatusUnprocessableEntity:
+		if apiErr.body.Errors == nil || len(*apiErr.body.Errors) == 0 {
+			return diag.Diagnostics{{Severity: diag.Error, Summary: summary + ": invalid request", Detail: apiErr.detail("")}}
+		}
+
+		var diags diag.Diagnostics
+		for _, ve := range *apiErr.body.Errors {
+			field, message := conv.Deref(ve.Field), conv.Deref(ve.Message)
+
+			d := diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  strings.TrimSpace(fmt.Sprintf("%s: %s %s", summary, field, message)),
+				Detail:   apiErr.detail(""),
+			}
+			if slice.Contains(attrs, field) {
+				d.AttributePath = cty.GetAttrPath(field)
+			}
+			diags = append(diags, d)
+		}
+		return diags
+	default:
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: apiErr.detail("")}}
+	}
+}
@@ -0,0 +1,340 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+// fakeResponse is a response of the fake Looker API.
+type fakeResponse struct {
+	status int
+	body   string
+}
+
+// newFakeLookerServer returns a client of a fake Looker API, which logs in with a fixed access token and serves all other requests with
+// handler. The paths of the requests to handler include the /api/4.0 prefix.
+func newFakeLookerServer(t *testing.T, handler http.Handler) *lookerClient {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/4.0/login" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"token","expires_in":3600,"token_type":"Bearer"}`)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return &lookerClient{
+		LookerSDK: sdk.NewLookerSDK(rtl.NewAuthSessionWithTransport(rtl.ApiSettings{
+			BaseUrl:    srv.URL,
+			ApiVersion: "4.0",
+		}, http.DefaultTransport)),
+		locks: newMutexKV(),
+	}
+}
+
+// newFakeLookerClient returns a client of a fake Looker API which replies to each "<method> <path>" in responses, and fails the test
+// if any other request is made.
+func newFakeLookerClient(t *testing.T, responses map[string]fakeResponse) *lookerClient {
+	t.Helper()
+
+	return newFakeLookerServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := responses[r.Method+" "+strings.TrimPrefix(r.URL.Path, "/api/4.0")]
+		if !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(res.status)
+		fmt.Fprint(w, res.body)
+	}))
+}
+
+const (
+	notFoundBody   = `{"message":"Not found","documentation_url":"https://cloud.google.com/looker/docs/"}`
+	conflictBody   = `{"message":"Group with name 'Orange' already exists","documentation_url":"https://cloud.google.com/looker/docs/"}`
+	validationBody = `{"message":"Validation Failed","errors":[{"field":"email","code":"already_exists","message":"has already been taken","documentation_url":"https://cloud.google.com/looker/docs/"},{"field":"user","code":"invalid","message":"is invalid","documentation_url":"https://cloud.google.com/looker/docs/"}],"documentation_url":"https://cloud.google.com/looker/docs/"}`
+)
+
+func TestAPIDiags(t *testing.T) {
+	tests := []struct {
+		name string
+		res  fakeResponse
+		want diag.Diagnostics
+	}{
+		{
+			name: "not found",
+			res:  fakeResponse{status: http.StatusNotFound, body: notFoundBody},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed to create user: not found",
+				Detail:   "Looker returned 404 Not Found: Not found\n\nThe object does not exist, or the API user does not have the permissions to see it.\n\nSee https://cloud.google.com/looker/docs/",
+			}},
+		},
+		{
+			name: "conflict",
+			res:  fakeResponse{status: http.StatusConflict, body: conflictBody},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed to create user: already exists",
+				Detail:   "Looker returned 409 Conflict: Group with name 'Orange' already exists\n\nAn object with the same unique attributes, eg. the same name, already exists in Looker. Import the existing object into the terraform state, or change its unique attributes.\n\nSee https://cloud.google.com/looker/docs/",
+			}},
+		},
+		{
+			name: "validation errors are reported on their attribute",
+			res:  fakeResponse{status: http.StatusUnprocessableEntity, body: validationBody},
+			want: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "failed to create user: email has already been taken",
+					Detail:        "Looker returned 422 Unprocessable Entity: Validation Failed\n\nSee https://cloud.google.com/looker/docs/",
+					AttributePath: cty.GetAttrPath("email"),
+				},
+				{
+					Severity: diag.Error,
+					Summary:  "failed to create user: user is invalid",
+					Detail:   "Looker returned 422 Unprocessable Entity: Validation Failed\n\nSee https://cloud.google.com/looker/docs/",
+				},
+			},
+		},
+		{
+			name: "validation failure without errors",
+			res:  fakeResponse{status: http.StatusUnprocessableEntity, body: `{"message":"Validation Failed"}`},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed to create user: invalid request",
+				Detail:   "Looker returned 422 Unprocessable Entity: Validation Failed",
+			}},
+		},
+		{
+			name: "body which is not json",
+			res:  fakeResponse{status: http.StatusBadGateway, body: "upstream connect error\n"},
+			want: diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "failed to create user",
+				Detail:   "Looker returned 502 Bad Gateway: upstream connect error",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeLookerClient(t, map[string]fakeResponse{"POST /users": tt.res})
+
+			_, err := c.CreateUser(sdk.WriteUser{}, "", nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			got := apiDiags(err, "failed to create user", "email")
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("unexpected diagnostics\n got: %#v\nwant: %#v", got, tt.want)
+			}
+			for i := range got {
+				if i < len(tt.want) && !got[i].AttributePath.Equals(tt.want[i].AttributePath) {
+					t.Errorf("diagnostic %d: expected attribute path %#v, got %#v", i, tt.want[i].AttributePath, got[i].AttributePath)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIDiagsWithoutAPIError(t *testing.T) {
+	if diags := apiDiags(nil, "failed to create user"); diags != nil {
+		t.Errorf("expected no diagnostics for a nil error, got %v", diags)
+	}
+
+	want := diag.Diagnostics{{Severity: diag.Error, Summary: "failed to create user", Detail: "connection refused"}}
+	if got := apiDiags(errors.New("connection refused"), "failed to create user"); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("unexpected diagnostics\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestResourceUserDeleteErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		deletionMode string
+		responses    map[string]fakeResponse
+		wantErr      bool
+	}{
+		{
+			name:         "deleted user",
+			deletionMode: "delete",
+			responses:    map[string]fakeResponse{"DELETE /users/7": {status: http.StatusNoContent}},
+		},
+		{
+			name:         "user which no longer exists",
+			deletionMode: "delete",
+			responses:    map[string]fakeResponse{"DELETE /users/7": {status: http.StatusNotFound, body: notFoundBody}},
+		},
+		{
+			name:         "delete is forbidden",
+			deletionMode: "delete",
+			responses:    map[string]fakeResponse{"DELETE /users/7": {status: http.StatusForbidden, body: `{"message":"Forbidden"}`}},
+			wantErr:      true,
+		},
+		{
+			name:         "disable is rejected",
+			deletionMode: "disable",
+			responses:    map[string]fakeResponse{"PATCH /users/7": {status: http.StatusUnprocessableEntity, body: `{"message":"Validation Failed"}`}},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeLookerClient(t, tt.responses)
+
+			d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+				"email":         "tina@orange.com",
+				"first_name":    "Tina",
+				"last_name":     "Turner",
+				"deletion_mode": tt.deletionMode,
+			})
+			d.SetId("7")
+
+			if diags := resourceUserDelete(context.Background(), d, c); diags.HasError() != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestResourceUserCreateReportsEmailValidationErrors(t *testing.T) {
+	c := newFakeLookerClient(t, map[string]fakeResponse{
+		"POST /users":                     {status: http.StatusOK, body: `{"id":"7"}`},
+		"POST /users/7/credentials_email": {status: http.StatusUnprocessableEntity, body: validationBody},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"email":      "tina@orange.com",
+		"first_name": "Tina",
+		"last_name":  "Turner",
+	})
+
+	diags := resourceUserCreate(context.Background(), d, c)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("email")) {
+		t.Errorf("expected the validation error of the email to be reported on the email attribute, got %#v", diags[0].AttributePath)
+	}
+	if d.Id() != "7" {
+		t.Errorf("expected the created user to be kept in the state, got id %q", d.Id())
+	}
+}
+
+func TestResourceUserRolesCreateSetErrors(t *testing.T) {
+	c := newFakeLookerClient(t, map[string]fakeResponse{
+		"GET /users/7/roles": {status: http.StatusOK, body: `[]`},
+		"PUT /users/7/roles": {status: http.StatusUnprocessableEntity, body: `{"message":"Validation Failed"}`},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceUserRoles().Schema, map[string]interface{}{
+		"user_id":  "7",
+		"role_ids": []interface{}{"2"},
+	})
+
+	diags := resourceUserRolesCreate(context.Background(), d, c)
+	if !diags.HasError() {
+		t.Fatal("expected the error setting the roles to be returned")
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no id to be set, got %q", d.Id())
+	}
+}
+
+func TestResourceUserRolesReadDeletedUser(t *testing.T) {
+	c := newFakeLookerClient(t, map[string]fakeResponse{
+		"GET /users/7/roles": {status: http.StatusNotFound, body: notFoundBody},
+	})
+
+	for _, authoritative := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, resourceUserRoles().Schema, map[string]interface{}{
+			"user_id":       "7",
+			"role_ids":      []interface{}{"2"},
+			"authoritative": authoritative,
+		})
+		d.SetId("7")
+
+		if diags := resourceUserRolesRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("authoritative %t: unexpected error: %v", authoritative, diags)
+		}
+		if d.Id() != "" {
+			t.Errorf("authoritative %t: expected the roles of a deleted user to be removed from the state", authoritative)
+		}
+	}
+}
+
+func TestResourceGroupUserDeleteErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		res     fakeResponse
+		wantErr bool
+	}{
+		{
+			name: "user which is no longer in the group",
+			res:  fakeResponse{status: http.StatusNotFound, body: notFoundBody},
+		},
+		{
+			name:    "server error",
+			res:     fakeResponse{status: http.StatusInternalServerError, body: `{"message":"Internal error"}`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeLookerClient(t, map[string]fakeResponse{"DELETE /groups/4/users/7": tt.res})
+
+			d := schema.TestResourceDataRaw(t, resourceGroupUser().Schema, map[string]interface{}{
+				"user_id":  "7",
+				"group_id": "4",
+			})
+			d.SetId("7_4")
+
+			if diags := resourceGroupUserDelete(context.Background(), d, c); diags.HasError() != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestImportInvalidIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource *schema.Resource
+	}{
+		{name: "looker_group_group", resource: resourceGroupGroup()},
+		{name: "looker_group_user", resource: resourceGroupUser()},
+		{name: "looker_role_groups", resource: resourceRoleGroups()},
+		{name: "looker_user_attribute_user", resource: resourceUserAttributeUser()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.resource.TestResourceData()
+			d.SetId("7")
+
+			if _, err := tt.resource.Importer.StateContext(context.Background(), d, &lookerClient{}); err == nil {
+				t.Error("expected an error for an id without a delimiter")
+			}
+		})
+	}
+}
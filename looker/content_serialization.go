@@ -0,0 +1,176 @@
+package looker
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// volatileContentFields are the fields of serialized content which are set by Looker, eg. ids and timestamps. They differ between
+// instances and between copies of the same content, so they are ignored when content is compared.
+var volatileContentFields = map[string]bool{
+	"can":                 true,
+	"id":                  true,
+	"dashboard_id":        true,
+	"content_metadata_id": true,
+	"content_favorite_id": true,
+	"created_at":          true,
+	"updated_at":          true,
+	"deleted_at":          true,
+	"deleter_id":          true,
+	"last_accessed_at":    true,
+	"last_viewed_at":      true,
+	"last_updater_id":     true,
+	"last_updater_name":   true,
+	"view_count":          true,
+	"favorite_count":      true,
+	"user_id":             true,
+	"user_name":           true,
+	"slug":                true,
+	"query_id":            true,
+	"result_maker_id":     true,
+	"client_id":           true,
+	"share_url":           true,
+	"expanded_share_url":  true,
+	"url":                 true,
+	"edit_uri":            true,
+	"folder":              true,
+	"folder_id":           true,
+	"space":               true,
+	"space_id":            true,
+}
+
+// volatileLookmlFields are the fields of dashboard LookML which are set by Looker. In addition to the volatile fields of JSON content,
+// a dashboard which is created from LookML is exported with a new name and slug.
+var volatileLookmlFields = func() map[string]bool {
+	fields := map[string]bool{
+		"dashboard":      true,
+		"preferred_slug": true,
+	}
+	for field := range volatileContentFields {
+		fields[field] = true
+	}
+	return fields
+}()
+
+// parseContentJSON parses serialized JSON content, without its volatile fields.
+func parseContentJSON(s string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return normalizeContent(v, volatileContentFields), nil
+}
+
+// parseContentLookml parses LookML content, without its volatile fields.
+func parseContentLookml(s string) (interface{}, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("invalid LookML: %w", err)
+	}
+	return normalizeContent(v, volatileLookmlFields), nil
+}
+
+// serializeContent serializes content returned by the Looker API as JSON, without its volatile fields.
+func serializeContent(content interface{}) (string, error) {
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+
+	v, err := parseContentJSON(string(b))
+	if err != nil {
+		return "", err
+	}
+
+	// the keys of maps are sorted when they are marshalled, so the serialization of the same content is stable
+	b, err = json.Marshal(v)
+	return string(b), err
+}
+
+// normalizeContent returns v without the volatile fields and null values of its objects, at any depth.
+func normalizeContent(v interface{}, volatile map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if volatile[key] || value == nil {
+				continue
+			}
+			m[key] = normalizeContent(value, volatile)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = normalizeContent(value, volatile)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// pruneContent returns remote with only the fields of its objects which are set in config, so that the fields of content which are
+// not managed by terraform, eg. the defaults added by Looker, are not compared. Lists are kept in full, so that an element which is
+// added to or removed from the content in Looker is detected as drift.
+func pruneContent(remote, config interface{}) interface{} {
+	switch config := config.(type) {
+	case map[string]interface{}:
+		r, ok := remote.(map[string]interface{})
+		if !ok {
+			return remote
+		}
+
+		pruned := make(map[string]interface{}, len(config))
+		for key, value := range config {
+			if rv, ok := r[key]; ok {
+				pruned[key] = pruneContent(rv, value)
+			}
+		}
+		return pruned
+	case []interface{}:
+		r, ok := remote.([]interface{})
+		if !ok {
+			return remote
+		}
+
+		pruned := make([]interface{}, len(r))
+		for i := range r {
+			if i < len(config) {
+				pruned[i] = pruneContent(r[i], config[i])
+			} else {
+				pruned[i] = r[i]
+			}
+		}
+		return pruned
+	default:
+		return remote
+	}
+}
+
+// equivalentContent returns true if the fields of the remote content which are set in config have the same values as in config.
+func equivalentContent(remote, config interface{}) bool {
+	return reflect.DeepEqual(pruneContent(remote, config), config)
+}
+
+// suppressEquivalentContent returns a DiffSuppressFunc which ignores the differences between the content in the state, which is
+// serialized from Looker, and the content in the config that are only in volatile fields or in fields which are not set in config.
+func suppressEquivalentContent(parse func(string) (interface{}, error)) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if old == "" || new == "" {
+			return old == new
+		}
+
+		remote, remoteErr := parse(old)
+		config, configErr := parse(new)
+		if remoteErr != nil || configErr != nil {
+			return false
+		}
+
+		return equivalentContent(remote, config)
+	}
+}
@@ -0,0 +1,139 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func TestSuppressEquivalentContent(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func(string) (interface{}, error)
+		remote string
+		config string
+		want   bool
+	}{
+		{
+			name:   "fields which are not in config are ignored",
+			parse:  parseContentJSON,
+			remote: `{"title":"Orders","hidden":false,"show_title":true,"dashboard_elements":[{"type":"text","body_text":"Hi","title_hidden":false}]}`,
+			config: `{"title":"Orders","dashboard_elements":[{"type":"text","body_text":"Hi"}]}`,
+			want:   true,
+		},
+		{
+			name:   "volatile fields are ignored",
+			parse:  parseContentJSON,
+			remote: `{"title":"Orders","id":"31","created_at":"2026-10-14T09:12:04.000+00:00","dashboard_elements":[{"id":"45","dashboard_id":"31","type":"text"}]}`,
+			config: `{"id":"7","title":"Orders","updated_at":"2020-01-01T00:00:00.000+00:00","dashboard_elements":[{"id":"2","type":"text"}]}`,
+			want:   true,
+		},
+		{
+			name:   "null values are ignored",
+			parse:  parseContentJSON,
+			remote: `{"title":"Orders"}`,
+			config: `{"title":"Orders","description":null}`,
+			want:   true,
+		},
+		{
+			name:   "changed value",
+			parse:  parseContentJSON,
+			remote: `{"title":"Orders","dashboard_elements":[{"type":"text","body_text":"Hi"}]}`,
+			config: `{"title":"Orders","dashboard_elements":[{"type":"text","body_text":"Hello"}]}`,
+			want:   false,
+		},
+		{
+			name:   "element added in looker",
+			parse:  parseContentJSON,
+			remote: `{"dashboard_elements":[{"type":"text"},{"type":"vis"}]}`,
+			config: `{"dashboard_elements":[{"type":"text"}]}`,
+			want:   false,
+		},
+		{
+			name:   "element removed in looker",
+			parse:  parseContentJSON,
+			remote: `{"dashboard_elements":[{"type":"text"}]}`,
+			config: `{"dashboard_elements":[{"type":"text"},{"type":"vis"}]}`,
+			want:   false,
+		},
+		{
+			name:  "lookml exported with a new name and slug",
+			parse: parseContentLookml,
+			remote: `
+- dashboard: orders_2
+  title: Orders
+  layout: newspaper
+  preferred_viewer: dashboards-next
+  preferred_slug: dJ3kzQ8vXw1pLs0aT5rYhe
+  elements:
+  - title: Welcome
+    name: Welcome
+    type: text
+    body_text: Orders by status
+    row: 0
+    col: 0
+`,
+			config: `
+- dashboard: orders
+  title: Orders
+  layout: newspaper
+  elements:
+  - title: Welcome
+    name: Welcome
+    type: text
+    body_text: Orders by status
+`,
+			want: true,
+		},
+		{
+			name:   "changed lookml",
+			parse:  parseContentLookml,
+			remote: "- dashboard: orders\n  title: Orders\n",
+			config: "- dashboard: orders\n  title: Orders by status\n",
+			want:   false,
+		},
+		{
+			name:   "invalid config",
+			parse:  parseContentJSON,
+			remote: `{"title":"Orders"}`,
+			config: `{"title":`,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressEquivalentContent(tt.parse)("json", tt.remote, tt.config, nil); got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSerializeContent(t *testing.T) {
+	type element struct {
+		Id       *string `json:"id,omitempty"`
+		BodyText *string `json:"body_text,omitempty"`
+		Note     *string `json:"note"`
+	}
+	content := struct {
+		Id       string    `json:"id"`
+		Title    string    `json:"title"`
+		Elements []element `json:"dashboard_elements"`
+	}{
+		Id:       "31",
+		Title:    "Orders",
+		Elements: []element{{Id: conv.P("45"), BodyText: conv.P("Hi")}},
+	}
+
+	got, err := serializeContent(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the keys are sorted, and the ids and null values are removed
+	want := `{"dashboard_elements":[{"body_text":"Hi"}],"title":"Orders"}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
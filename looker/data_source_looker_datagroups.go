@@ -0,0 +1,147 @@
+package looker
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func dataSourceDatagroups() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"model_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only return the datagroups of this LookML model",
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The ids of the datagroups, in the same order as `datagroups`",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"datagroups": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The datagroups which match the filters, ordered by name",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the datagroup",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the datagroup",
+					},
+					"model_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the LookML model of the datagroup",
+					},
+					"trigger_value": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The value of the SQL trigger of the datagroup when it was last checked",
+					},
+					"trigger_error": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The error of the SQL trigger of the datagroup when it was last checked, if any",
+					},
+					"trigger_check_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The time at which the trigger of the datagroup was last checked, in RFC 3339 format",
+					},
+					"triggered_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The time at which the datagroup was last triggered, in RFC 3339 format",
+					},
+					"stale_before": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The time before which the cache entries of the datagroup are stale, in RFC 3339 format",
+					},
+				},
+			},
+		},
+	}
+	for k, v := range nameFilterSchema("datagroups") {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description: "This data source lists the datagroups of the LookML models of a Looker instance, with the state of their triggers and caches. All datagroups are returned if no filters are set.",
+
+		ReadContext: dataSourceDatagroupsRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceDatagroupsRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	allDatagroups, datagroupsErr := api.AllDatagroups(nil)
+	if datagroupsErr != nil {
+		return diag.FromErr(datagroupsErr)
+	}
+
+	modelName := d.Get("model_name").(string)
+	if modelName != "" {
+		inModel := make([]sdk.Datagroup, 0, len(allDatagroups))
+		for _, dg := range allDatagroups {
+			if conv.Deref(dg.ModelName) == modelName {
+				inModel = append(inModel, dg)
+			}
+		}
+		allDatagroups = inModel
+	}
+
+	datagroups, filterErr := filterByName(d, allDatagroups, func(dg sdk.Datagroup) string { return conv.Deref(dg.Name) })
+	if filterErr != nil {
+		return diag.FromErr(filterErr)
+	}
+
+	ids := make([]string, 0, len(datagroups))
+	dgs := make([]interface{}, 0, len(datagroups))
+	for _, dg := range datagroups {
+		ids = append(ids, conv.Deref(dg.Id))
+		dgs = append(dgs, map[string]interface{}{
+			"id":               conv.Deref(dg.Id),
+			"name":             conv.Deref(dg.Name),
+			"model_name":       conv.Deref(dg.ModelName),
+			"trigger_value":    conv.Deref(dg.TriggerValue),
+			"trigger_error":    conv.Deref(dg.TriggerError),
+			"trigger_check_at": formatUnixTime(dg.TriggerCheckAt),
+			"triggered_at":     formatUnixTime(dg.TriggeredAt),
+			"stale_before":     formatUnixTime(dg.StaleBefore),
+		})
+	}
+
+	d.SetId(nameFilterID(d) + "/" + modelName)
+	result := multierror.Append(
+		d.Set("ids", ids),
+		d.Set("datagroups", dgs),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+// formatUnixTime returns a unix timestamp of the Looker API in RFC 3339 format, or an empty string if it is not set.
+func formatUnixTime(t *int64) string {
+	if t == nil || *t == 0 {
+		return ""
+	}
+	return time.Unix(*t, 0).UTC().Format(time.RFC3339)
+}
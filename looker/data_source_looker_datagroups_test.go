@@ -0,0 +1,48 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLookerDatagroups(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_data_datagroups")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "looker_datagroups" "thelook" {
+					model_name = "thelook"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "ids.0", "3"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "ids.1", "4"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "datagroups.0.name", "orders_datagroup"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "datagroups.0.trigger_value", "2026-10-14 08:00:00"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "datagroups.0.triggered_at", "2026-10-14T08:00:00Z"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "datagroups.0.stale_before", ""),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "datagroups.1.name", "users_datagroup"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.thelook", "datagroups.1.trigger_check_at", "2026-10-14T09:05:00Z"),
+				),
+			},
+			{
+				Config: `
+				data "looker_datagroups" "events" {
+					name_regex = "^events_"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_datagroups.events", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.events", "datagroups.0.model_name", "events"),
+					resource.TestCheckResourceAttr("data.looker_datagroups.events", "datagroups.0.trigger_error", "Table 'events.etl_runs' doesn't exist"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,98 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func dataSourceGroups() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The ids of the groups, in the same order as `groups`",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"groups": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The groups which match the filters, ordered by name",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the group",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the group",
+					},
+					"user_count": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of users who are direct members of the group",
+					},
+					"externally_managed": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the group is managed by an external system, eg. SAML or LDAP",
+					},
+				},
+			},
+		},
+	}
+	for k, v := range nameFilterSchema("groups") {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description: "This data source lists the groups of a Looker instance. All groups are returned if no filters are set.",
+
+		ReadContext: dataSourceGroupsRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	allGroups, groupsErr := api.AllGroups(sdk.RequestAllGroups{}, nil)
+	if groupsErr != nil {
+		return diag.FromErr(groupsErr)
+	}
+
+	groups, filterErr := filterByName(d, allGroups, func(g sdk.Group) string { return conv.Deref(g.Name) })
+	if filterErr != nil {
+		return diag.FromErr(filterErr)
+	}
+
+	ids := make([]string, 0, len(groups))
+	gs := make([]interface{}, 0, len(groups))
+	for _, g := range groups {
+		ids = append(ids, conv.Deref(g.Id))
+		gs = append(gs, map[string]interface{}{
+			"id":                 conv.Deref(g.Id),
+			"name":               conv.Deref(g.Name),
+			"user_count":         conv.Deref(g.UserCount),
+			"externally_managed": conv.Deref(g.ExternallyManaged),
+		})
+	}
+
+	d.SetId(nameFilterID(d))
+	result := multierror.Append(
+		d.Set("ids", ids),
+		d.Set("groups", gs),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
@@ -0,0 +1,34 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLookerGroups(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_data_groups")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "looker_groups" "saml" {
+					name_regex = "^saml_"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_groups.saml", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.looker_groups.saml", "groups.0.id", "6"),
+					resource.TestCheckResourceAttr("data.looker_groups.saml", "groups.0.name", "saml_admins"),
+					resource.TestCheckResourceAttr("data.looker_groups.saml", "groups.0.user_count", "3"),
+					resource.TestCheckResourceAttr("data.looker_groups.saml", "groups.0.externally_managed", "true"),
+					resource.TestCheckResourceAttr("data.looker_groups.saml", "groups.1.id", "8"),
+					resource.TestCheckResourceAttr("data.looker_groups.saml", "groups.1.name", "saml_viewers"),
+				),
+			},
+		},
+	})
+}
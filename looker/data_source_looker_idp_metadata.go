@@ -3,82 +3,104 @@ package looker
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
-
-	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
-// TODO: Add a test for this datasource
 func dataSourceLookerIdpMetadata() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"idp_metadata_url": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"idp_metadata_url", "idp_metadata_xml"},
+			Description:  "SAML Metadata URL. The metadata is fetched and parsed by the Looker instance",
+		},
+		"idp_metadata_xml": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"idp_metadata_url", "idp_metadata_xml"},
+			Description:  "SAML Metadata XML. The metadata is parsed by the provider, without a request to the Looker instance",
+		},
+		"idp_issuer": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Identify Provider Issuer",
+		},
+		"idp_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Identify Provider URL",
+		},
+		"idp_cert": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Identify Provider Certificate",
+		},
+	}
+
+	for k, v := range idpCertSchema() {
+		s[k] = v
+	}
+
 	return &schema.Resource{
-		Description: "This datasource parsed IdP metadata.",
+		Description: "This datasource parses IdP metadata. Reading the data source fails if the certificate of the IdP cannot be parsed or has expired, and warns if it expires within 30 days.",
 
 		ReadContext: dataSourceIdpMetadataRead,
-		Schema: map[string]*schema.Schema{
-			"idp_metadata_url": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ExactlyOneOf: []string{"idp_metadata_url", "idp_metadata_xml"},
-				Description:  "SAML Metadata URL",
-			},
-			"idp_metadata_xml": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ExactlyOneOf: []string{"idp_metadata_url", "idp_metadata_xml"},
-				Description:  "SAML Metadata XML",
-			},
-			"idp_issuer": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Identify Provider Issuer",
-			},
-			"idp_url": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Identify Provider URL",
-			},
-			"idp_cert": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Identify Provider Certificate",
-			},
-		},
+		Schema:      s,
 	}
 }
 
 func dataSourceIdpMetadataRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	// exactly one of these variables will be nil - this is enforced by the data source schema
 	url := conv.PString(d.Get("idp_metadata_url").(string))
 	xml := conv.PString(d.Get("idp_metadata_xml").(string))
 
-	var res sdk.SamlMetadataParseResult
-	var err error
-
+	var md idpMetadata
 	switch {
 	case xml != nil:
-		res, err = api.ParseSamlIdpMetadata(*xml, nil)
+		// metadata XML is parsed locally, so that invalid metadata is caught without a request to the Looker API
+		var err error
+		if md, err = parseIdpMetadata(*xml); err != nil {
+			return diag.FromErr(err)
+		}
 	case url != nil:
-		res, err = api.FetchAndParseSamlIdpMetadata(*url, nil)
+		res, err := api.FetchAndParseSamlIdpMetadata(*url, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		md = idpMetadata{
+			Issuer: conv.Deref(res.IdpIssuer),
+			URL:    conv.Deref(res.IdpUrl),
+			Cert:   conv.Deref(res.IdpCert),
+		}
 	default:
-		err = errors.New("missing URL or XML")
+		return diag.FromErr(errors.New("missing URL or XML"))
 	}
 
-	if err != nil {
-		return diag.FromErr(err)
+	cert, certErr := parseIdpCert(md.Cert)
+	if certErr != nil {
+		return diag.FromErr(certErr)
 	}
 
 	result := multierror.Append(
-		d.Set("idp_url", res.IdpUrl),
-		d.Set("idp_issuer", res.IdpIssuer),
-		d.Set("idp_cert", res.IdpCert),
+		d.Set("idp_url", md.URL),
+		d.Set("idp_issuer", md.Issuer),
+		d.Set("idp_cert", md.Cert),
+		setIdpCert(d, md.Cert),
 	)
+	if err := result.ErrorOrNil(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(md.URL)
 
-	d.SetId(*res.IdpUrl)
-	return diag.FromErr(result.ErrorOrNil())
+	// an expired certificate is an error, as the config of a looker_saml_config using it would fail to plan
+	return checkIdpCert(cert, time.Now(), cty.GetAttrPath("idp_cert"))
 }
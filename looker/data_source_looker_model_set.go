@@ -42,7 +42,7 @@ func dataSourceModelSet() *schema.Resource {
 }
 
 func dataSourceModelSetRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	name := conv.PString(d.Get("name").(string))
 	id := conv.PString(d.Get("id").(string))
@@ -0,0 +1,107 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func dataSourceModelSets() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The ids of the model sets, in the same order as `model_sets`",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"model_sets": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The model sets which match the filters, ordered by name",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the model set",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the model set",
+					},
+					"models": {
+						Type:        schema.TypeSet,
+						Computed:    true,
+						Description: "The models within the model set",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"built_in": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the model set is built into Looker",
+					},
+					"all_access": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the model set has every model",
+					},
+				},
+			},
+		},
+	}
+	for k, v := range nameFilterSchema("model sets") {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description: "This data source lists the model sets of a Looker instance. All model sets are returned if no filters are set.",
+
+		ReadContext: dataSourceModelSetsRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceModelSetsRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	allModelSets, modelSetsErr := api.AllModelSets("", nil)
+	if modelSetsErr != nil {
+		return diag.FromErr(modelSetsErr)
+	}
+
+	modelSets, filterErr := filterByName(d, allModelSets, func(m sdk.ModelSet) string { return conv.Deref(m.Name) })
+	if filterErr != nil {
+		return diag.FromErr(filterErr)
+	}
+
+	ids := make([]string, 0, len(modelSets))
+	ms := make([]interface{}, 0, len(modelSets))
+	for _, m := range modelSets {
+		ids = append(ids, conv.Deref(m.Id))
+		ms = append(ms, map[string]interface{}{
+			"id":         conv.Deref(m.Id),
+			"name":       conv.Deref(m.Name),
+			"models":     conv.Deref(m.Models),
+			"built_in":   conv.Deref(m.BuiltIn),
+			"all_access": conv.Deref(m.AllAccess),
+		})
+	}
+
+	d.SetId(nameFilterID(d))
+	result := multierror.Append(
+		d.Set("ids", ids),
+		d.Set("model_sets", ms),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
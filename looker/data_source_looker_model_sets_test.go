@@ -0,0 +1,33 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLookerModelSets(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_data_model_sets")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "looker_model_sets" "lemon" {
+					name_prefix = "tf_"
+					name_regex  = "lemon$"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_model_sets.lemon", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.looker_model_sets.lemon", "ids.0", "4"),
+					resource.TestCheckResourceAttr("data.looker_model_sets.lemon", "model_sets.0.name", "tf_lemon"),
+					resource.TestCheckResourceAttr("data.looker_model_sets.lemon", "model_sets.0.models.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.looker_model_sets.lemon", "model_sets.0.models.*", "lemon"),
+				),
+			},
+		},
+	})
+}
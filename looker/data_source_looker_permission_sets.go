@@ -0,0 +1,107 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func dataSourcePermissionSets() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The ids of the permission sets, in the same order as `permission_sets`",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"permission_sets": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The permission sets which match the filters, ordered by name",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the permission set",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the permission set",
+					},
+					"permissions": {
+						Type:        schema.TypeSet,
+						Computed:    true,
+						Description: "The permissions within the permission set",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"built_in": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the permission set is built into Looker",
+					},
+					"all_access": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the permission set has every permission",
+					},
+				},
+			},
+		},
+	}
+	for k, v := range nameFilterSchema("permission sets") {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description: "This data source lists the permission sets of a Looker instance. All permission sets are returned if no filters are set.",
+
+		ReadContext: dataSourcePermissionSetsRead,
+		Schema:      s,
+	}
+}
+
+func dataSourcePermissionSetsRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	allPermSets, permSetsErr := api.AllPermissionSets("", nil)
+	if permSetsErr != nil {
+		return diag.FromErr(permSetsErr)
+	}
+
+	permSets, filterErr := filterByName(d, allPermSets, func(p sdk.PermissionSet) string { return conv.Deref(p.Name) })
+	if filterErr != nil {
+		return diag.FromErr(filterErr)
+	}
+
+	ids := make([]string, 0, len(permSets))
+	ps := make([]interface{}, 0, len(permSets))
+	for _, p := range permSets {
+		ids = append(ids, conv.Deref(p.Id))
+		ps = append(ps, map[string]interface{}{
+			"id":          conv.Deref(p.Id),
+			"name":        conv.Deref(p.Name),
+			"permissions": conv.Deref(p.Permissions),
+			"built_in":    conv.Deref(p.BuiltIn),
+			"all_access":  conv.Deref(p.AllAccess),
+		})
+	}
+
+	d.SetId(nameFilterID(d))
+	result := multierror.Append(
+		d.Set("ids", ids),
+		d.Set("permission_sets", ps),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
@@ -0,0 +1,32 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLookerPermissionSets(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_data_permission_sets")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "looker_permission_sets" "all" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_permission_sets.all", "ids.#", "4"),
+					resource.TestCheckResourceAttr("data.looker_permission_sets.all", "permission_sets.0.name", "Admin"),
+					resource.TestCheckResourceAttr("data.looker_permission_sets.all", "permission_sets.0.all_access", "true"),
+					resource.TestCheckResourceAttr("data.looker_permission_sets.all", "permission_sets.1.name", "Developer"),
+					resource.TestCheckResourceAttr("data.looker_permission_sets.all", "permission_sets.1.built_in", "true"),
+					resource.TestCheckResourceAttr("data.looker_permission_sets.all", "permission_sets.3.name", "tf_viewer"),
+					resource.TestCheckTypeSetElemAttr("data.looker_permission_sets.all", "permission_sets.3.permissions.*", "see_user_dashboards"),
+				),
+			},
+		},
+	})
+}
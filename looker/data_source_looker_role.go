@@ -88,7 +88,7 @@ func dataSourceRole() *schema.Resource {
 }
 
 func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	// exactly one of these variables will be nil - this is enforced by the data source schema
 	name := conv.PString(d.Get("name").(string))
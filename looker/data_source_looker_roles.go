@@ -0,0 +1,98 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func dataSourceRoles() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The ids of the roles, in the same order as `roles`",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"roles": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The roles which match the filters, ordered by name",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the role",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the role",
+					},
+					"permission_set_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the permission set of the role",
+					},
+					"model_set_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the model set of the role",
+					},
+				},
+			},
+		},
+	}
+	for k, v := range nameFilterSchema("roles") {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description: "This data source lists the roles of a Looker instance. All roles are returned if no filters are set.",
+
+		ReadContext: dataSourceRolesRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	allRoles, rolesErr := api.AllRoles(sdk.RequestAllRoles{}, nil)
+	if rolesErr != nil {
+		return diag.FromErr(rolesErr)
+	}
+
+	roles, filterErr := filterByName(d, allRoles, func(r sdk.Role) string { return conv.Deref(r.Name) })
+	if filterErr != nil {
+		return diag.FromErr(filterErr)
+	}
+
+	ids := make([]string, 0, len(roles))
+	rs := make([]interface{}, 0, len(roles))
+	for _, r := range roles {
+		ids = append(ids, conv.Deref(r.Id))
+		rs = append(rs, map[string]interface{}{
+			"id":                conv.Deref(r.Id),
+			"name":              conv.Deref(r.Name),
+			"permission_set_id": conv.Deref(r.PermissionSetId),
+			"model_set_id":      conv.Deref(r.ModelSetId),
+		})
+	}
+
+	d.SetId(nameFilterID(d))
+	result := multierror.Append(
+		d.Set("ids", ids),
+		d.Set("roles", rs),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
@@ -0,0 +1,34 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLookerRoles(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_data_roles")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "looker_roles" "tf" {
+					name_prefix = "tf_"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_roles.tf", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.looker_roles.tf", "ids.0", "5"),
+					resource.TestCheckResourceAttr("data.looker_roles.tf", "ids.1", "4"),
+					resource.TestCheckResourceAttr("data.looker_roles.tf", "roles.0.name", "tf_analyst"),
+					resource.TestCheckResourceAttr("data.looker_roles.tf", "roles.0.permission_set_id", "6"),
+					resource.TestCheckResourceAttr("data.looker_roles.tf", "roles.1.name", "tf_viewer"),
+					resource.TestCheckResourceAttr("data.looker_roles.tf", "roles.1.model_set_id", "3"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,161 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func dataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source reads a user from a Looker instance, including users who were not created by terraform, eg. users provisioned by SAML.",
+
+		ReadContext: dataSourceUserRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"email", "id"},
+				Description:  "The email address of the user. This field is case insensitive.",
+			},
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"email", "id"},
+				Description:  "The id of the user",
+			},
+			"first_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The first name of the user",
+			},
+			"last_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last name of the user",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full name of the user",
+			},
+			"is_disabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user is disabled",
+			},
+			"credential_types": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The types of the credentials the user has, which are any of `email`, `google`, `ldap`, `oidc`, `saml`, `api3`, `embed`, `totp` and `looker_openid`",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"group_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The ids of the groups the user is a direct member of",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"role_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The ids of the roles assigned directly to the user",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// exactly one of these variables will be nil - this is enforced by the data source schema
+	email := conv.PString(d.Get("email").(string))
+	id := conv.PString(d.Get("id").(string))
+
+	var user *sdk.User
+	if id != nil {
+		u, userErr := api.User(*id, "", nil)
+		if errors.Is(userErr, sdk.ErrNotFound) {
+			return diag.Errorf("user with id %s not found", *id)
+		}
+		if userErr != nil {
+			return diag.FromErr(userErr)
+		}
+		user = &u
+	} else {
+		// the email filter of the search matches wildcards, so the email of each user is compared to find the user
+		users, usersErr := api.SearchUsers(sdk.RequestSearchUsers{
+			Email: email,
+		}, nil)
+		if usersErr != nil {
+			return diag.FromErr(usersErr)
+		}
+
+		for _, u := range users {
+			if u.Email != nil && strings.EqualFold(*email, *u.Email) {
+				user = &u
+				break
+			}
+		}
+		if user == nil {
+			return diag.Errorf("user with email '%s' not found", *email)
+		}
+	}
+
+	if user.Id == nil {
+		return diag.Errorf("user id not set")
+	}
+	d.SetId(*user.Id)
+
+	result := multierror.Append(
+		d.Set("id", user.Id),
+		d.Set("email", user.Email),
+		d.Set("first_name", user.FirstName),
+		d.Set("last_name", user.LastName),
+		d.Set("display_name", user.DisplayName),
+		d.Set("is_disabled", user.IsDisabled),
+		d.Set("credential_types", flattenCredentialTypes(*user)),
+		d.Set("group_ids", conv.Deref(user.GroupIds)),
+		d.Set("role_ids", conv.Deref(user.RoleIds)),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+// flattenCredentialTypes returns the types of the credentials of the user.
+func flattenCredentialTypes(u sdk.User) []string {
+	var types []string
+	for credType, ok := range map[string]bool{
+		"email":         u.CredentialsEmail != nil,
+		"google":        u.CredentialsGoogle != nil,
+		"ldap":          u.CredentialsLdap != nil,
+		"oidc":          u.CredentialsOidc != nil,
+		"saml":          u.CredentialsSaml != nil,
+		"api3":          u.CredentialsApi3 != nil && len(*u.CredentialsApi3) > 0,
+		"embed":         u.CredentialsEmbed != nil && len(*u.CredentialsEmbed) > 0,
+		"totp":          u.CredentialsTotp != nil,
+		"looker_openid": u.CredentialsLookerOpenid != nil,
+	} {
+		if ok {
+			types = append(types, credType)
+		}
+	}
+
+	return types
+}
@@ -0,0 +1,50 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLookerUser(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_data_user")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// the _ of the email is a wildcard of the search, which also matches tinaxturner@orange.com
+				Config: `
+				data "looker_user" "tina" {
+					email = "tina_turner@orange.com"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_user.tina", "id", "7"),
+					resource.TestCheckResourceAttr("data.looker_user.tina", "first_name", "Tina"),
+					resource.TestCheckResourceAttr("data.looker_user.tina", "last_name", "Turner"),
+					resource.TestCheckResourceAttr("data.looker_user.tina", "is_disabled", "false"),
+					resource.TestCheckResourceAttr("data.looker_user.tina", "credential_types.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.looker_user.tina", "credential_types.*", "saml"),
+					resource.TestCheckTypeSetElemAttr("data.looker_user.tina", "group_ids.*", "4"),
+					resource.TestCheckTypeSetElemAttr("data.looker_user.tina", "role_ids.*", "2"),
+				),
+			},
+			{
+				Config: `
+				data "looker_user" "tina" {
+					id = "7"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_user.tina", "email", "tina_turner@orange.com"),
+					resource.TestCheckResourceAttr("data.looker_user.tina", "display_name", "Tina Turner"),
+					resource.TestCheckResourceAttr("data.looker_user.tina", "credential_types.#", "3"),
+					resource.TestCheckTypeSetElemAttr("data.looker_user.tina", "credential_types.*", "email"),
+					resource.TestCheckTypeSetElemAttr("data.looker_user.tina", "credential_types.*", "api3"),
+				),
+			},
+		},
+	})
+}
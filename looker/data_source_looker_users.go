@@ -0,0 +1,214 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+// searchPageSize is the number of results requested in each page of a search.
+const searchPageSize = 100
+
+// userSearchFields are the fields of the users returned by the search of the looker_users data source.
+const userSearchFields = "id,email,first_name,last_name,display_name,is_disabled,group_ids,role_ids"
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source searches the users of a Looker instance. All users are returned if no filters are set. The `email`, `first_name` and `last_name` filters are case insensitive, and `%` matches any number of characters, eg. `%@mydomain.com` matches the users with an email address at mydomain.com.",
+
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the users with a matching email address",
+			},
+			"first_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the users with a matching first name",
+			},
+			"last_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the users with a matching last name",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the users who are direct members of the group with this id",
+			},
+			"is_disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return the users who are disabled if true, or the users who are not disabled if false",
+			},
+			"embed_user": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return embed users if true, or the users who are not embed users if false",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ids of the users, in the same order as `users`",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users which match the filters, ordered by id",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the user",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The email address of the user",
+						},
+						"first_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The first name of the user",
+						},
+						"last_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The last name of the user",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The full name of the user",
+						},
+						"is_disabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user is disabled",
+						},
+						"group_ids": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "The ids of the groups the user is a direct member of",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"role_ids": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Description: "The ids of the roles assigned directly to the user",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	req := sdk.RequestSearchUsers{
+		Fields:    conv.P(userSearchFields),
+		Sorts:     conv.P("id"),
+		Limit:     conv.P(int64(searchPageSize)),
+		Email:     conv.PString(d.Get("email").(string)),
+		FirstName: conv.PString(d.Get("first_name").(string)),
+		LastName:  conv.PString(d.Get("last_name").(string)),
+		GroupId:   conv.PString(d.Get("group_id").(string)),
+	}
+	// GetOk cannot tell if a bool filter has been set to false
+	if v, ok := d.GetOkExists("is_disabled"); ok { //nolint:staticcheck
+		req.IsDisabled = conv.P(v.(bool))
+	}
+	if v, ok := d.GetOkExists("embed_user"); ok { //nolint:staticcheck
+		req.EmbedUser = conv.P(v.(bool))
+	}
+
+	users, searchErr := searchAllUsers(api, req)
+	if searchErr != nil {
+		return diag.FromErr(searchErr)
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		if u.Id == nil {
+			return diag.Errorf("the search returned a user with a missing id")
+		}
+		ids = append(ids, *u.Id)
+	}
+
+	// the id of the data source identifies the filters of the search
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s/%s/%s/%s/%s/%s",
+		conv.Deref(req.Email), conv.Deref(req.FirstName), conv.Deref(req.LastName), conv.Deref(req.GroupId),
+		formatBoolFilter(req.IsDisabled), formatBoolFilter(req.EmbedUser),
+	))))
+
+	result := multierror.Append(
+		d.Set("ids", ids),
+		d.Set("users", flattenUsers(users)),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+// searchAllUsers pages through the results of a search of users, and returns the users of every page.
+func searchAllUsers(api *sdk.LookerSDK, req sdk.RequestSearchUsers) ([]sdk.User, error) {
+	var users []sdk.User
+	for offset := int64(0); ; offset += searchPageSize {
+		req.Offset = conv.P(offset)
+
+		page, err := api.SearchUsers(req, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search users: %w", err)
+		}
+		users = append(users, page...)
+
+		if len(page) < searchPageSize {
+			return users, nil
+		}
+	}
+}
+
+// formatBoolFilter formats a filter of a search, which is empty if the filter is not set.
+func formatBoolFilter(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func flattenUsers(users []sdk.User) []interface{} {
+	us := make([]interface{}, 0, len(users))
+	for _, u := range users {
+		us = append(us, map[string]interface{}{
+			"id":           conv.Deref(u.Id),
+			"email":        conv.Deref(u.Email),
+			"first_name":   conv.Deref(u.FirstName),
+			"last_name":    conv.Deref(u.LastName),
+			"display_name": conv.Deref(u.DisplayName),
+			"is_disabled":  conv.Deref(u.IsDisabled),
+			"group_ids":    conv.Deref(u.GroupIds),
+			"role_ids":     conv.Deref(u.RoleIds),
+		})
+	}
+
+	return us
+}
@@ -0,0 +1,89 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func TestAccDataSourceLookerUsers(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_data_users")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "looker_users" "orange" {
+					email       = "%@orange.com"
+					is_disabled = false
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.looker_users.orange", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.looker_users.orange", "ids.0", "7"),
+					resource.TestCheckResourceAttr("data.looker_users.orange", "ids.1", "12"),
+					resource.TestCheckResourceAttr("data.looker_users.orange", "users.0.email", "tina@orange.com"),
+					resource.TestCheckResourceAttr("data.looker_users.orange", "users.0.display_name", "Tina Turner"),
+					resource.TestCheckTypeSetElemAttr("data.looker_users.orange", "users.0.group_ids.*", "4"),
+					resource.TestCheckTypeSetElemAttr("data.looker_users.orange", "users.0.role_ids.*", "2"),
+					resource.TestCheckResourceAttr("data.looker_users.orange", "users.1.first_name", "Nina"),
+					resource.TestCheckResourceAttr("data.looker_users.orange", "users.1.role_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceUsersPagesThroughResults(t *testing.T) {
+	var queries []url.Values
+	// the login of the client is served by the fake server, so only the searches are recorded
+	c := newFakeLookerServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// the instance has 2.5 pages of users
+		var users []sdk.User
+		for i := offset; i < offset+searchPageSize && i < 2*searchPageSize+50; i++ {
+			users = append(users, sdk.User{Id: conv.P(strconv.Itoa(i + 1))})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(users) //nolint:errcheck
+	}))
+
+	d := schema.TestResourceDataRaw(t, dataSourceUsers().Schema, map[string]interface{}{
+		"group_id": "4",
+	})
+	if diags := dataSourceUsersRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("failed to read users: %v", diags)
+	}
+
+	if len(queries) != 3 {
+		t.Fatalf("expected 3 pages to be requested, got %d: %v", len(queries), queries)
+	}
+	for i, q := range queries {
+		if offset := strconv.Itoa(i * searchPageSize); q.Get("offset") != offset || q.Get("group_id") != "4" {
+			t.Errorf("expected page %d to be requested with offset %s and group_id 4, got %v", i, offset, q)
+		}
+	}
+
+	if ids := d.Get("ids").([]interface{}); len(ids) != 250 || ids[249] != "250" {
+		t.Errorf("expected the ids of all 250 users, got %d", len(ids))
+	}
+}
@@ -0,0 +1,162 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/slice"
+)
+
+// newRoleGroupsServer returns a fake Looker API which stores the groups of roles. Reading the groups of a role is slow, which widens
+// the window in which concurrent read-modify-write operations on the same role can overwrite each other.
+func newRoleGroupsServer(t *testing.T) (*httptest.Server, func(roleID string) []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	roleGroups := map[string][]string{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/4.0/login" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"token","expires_in":3600,"token_type":"Bearer"}`)
+			return
+		}
+
+		roleID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/4.0/roles/"), "/groups")
+
+		switch r.Method {
+		case http.MethodGet:
+			mu.Lock()
+			groupIDs := append([]string{}, roleGroups[roleID]...)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			groups := make([]sdk.Group, len(groupIDs))
+			for i := range groupIDs {
+				groups[i] = sdk.Group{Id: &groupIDs[i]}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(groups) //nolint:errcheck
+		case http.MethodPut:
+			var groupIDs []string
+			if err := json.NewDecoder(r.Body).Decode(&groupIDs); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			// looker ignores duplicate group ids
+			unique := []string{}
+			for _, id := range groupIDs {
+				if !slice.Contains(unique, id) {
+					unique = append(unique, id)
+				}
+			}
+
+			mu.Lock()
+			roleGroups[roleID] = unique
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, "[]")
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func(roleID string) []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return roleGroups[roleID]
+	}
+}
+
+func TestRoleGroupsConcurrentCreate(t *testing.T) {
+	srv, getRoleGroups := newRoleGroupsServer(t)
+
+	c := &lookerClient{
+		LookerSDK: sdk.NewLookerSDK(rtl.NewAuthSessionWithTransport(rtl.ApiSettings{
+			BaseUrl:    srv.URL,
+			ApiVersion: "4.0",
+		}, http.DefaultTransport)),
+		locks: newMutexKV(),
+	}
+
+	// each resource grants the same role to a different group, as terraform does when applying resources in parallel
+	const resources = 10
+	var wg sync.WaitGroup
+	for i := 0; i < resources; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			d := schema.TestResourceDataRaw(t, resourceRoleGroups().Schema, map[string]interface{}{
+				"role_id":   "1",
+				"group_ids": []interface{}{fmt.Sprint(100 + i)},
+			})
+			if diags := resourceRoleGroupsCreate(context.Background(), d, c); diags.HasError() {
+				t.Errorf("failed to create role groups: %v", diags)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	expected := make([]string, resources)
+	for i := range expected {
+		expected[i] = fmt.Sprint(100 + i)
+	}
+	if actual := getRoleGroups("1"); !slice.UnorderedEqual(actual, expected) {
+		t.Errorf("groups on role do not match, updates have been lost. expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestMutexKV(t *testing.T) {
+	m := newMutexKV()
+
+	m.Lock("a")
+
+	// a different key is not blocked
+	done := make(chan struct{})
+	go func() {
+		m.Lock("b")
+		m.Unlock("b")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock on key b is blocked by lock on key a")
+	}
+
+	// the same key is blocked until it is unlocked
+	locked := make(chan struct{})
+	go func() {
+		m.Lock("a")
+		close(locked)
+		m.Unlock("a")
+	}()
+	select {
+	case <-locked:
+		t.Fatal("lock on key a was acquired twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.Unlock("a")
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("lock on key a was not released")
+	}
+}
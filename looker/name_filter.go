@@ -0,0 +1,72 @@
+package looker
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/slice"
+)
+
+// nameFilterSchema returns the attributes which filter the objects listed by a plural data source by their name.
+func nameFilterSchema(objects string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name_prefix": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Only return the %s with a name that starts with this prefix. This field is case sensitive.", objects),
+		},
+		"name_regex": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			Description:      fmt.Sprintf("Only return the %s with a name that matches this regular expression, in the [RE2 syntax](https://github.com/google/re2/wiki/Syntax)", objects),
+		},
+	}
+}
+
+// filterByName returns the objects with a name that satisfies the name_prefix and name_regex of the data source, sorted by name.
+func filterByName[T any](d *schema.ResourceData, objects []T, name func(T) string) ([]T, error) {
+	var predicates []slice.Predicate[string]
+	if prefix, ok := d.GetOk("name_prefix"); ok {
+		predicates = append(predicates, slice.StartsWithString(prefix.(string)))
+	}
+	if expr, ok := d.GetOk("name_regex"); ok {
+		re, err := regexp.Compile(expr.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid name_regex: %w", err)
+		}
+		predicates = append(predicates, slice.MatchesRegexp(re))
+	}
+
+	filtered := make([]T, 0, len(objects))
+	for _, obj := range objects {
+		if matchesAll(predicates, name(obj)) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return name(filtered[i]) < name(filtered[j])
+	})
+
+	return filtered, nil
+}
+
+func matchesAll(predicates []slice.Predicate[string], elem string) bool {
+	for _, p := range predicates {
+		if !p(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+// nameFilterID returns the id of a plural data source, which identifies its name filters.
+func nameFilterID(d *schema.ResourceData) string {
+	return strconv.Itoa(schema.HashString(d.Get("name_prefix").(string) + "/" + d.Get("name_regex").(string)))
+}
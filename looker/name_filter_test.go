@@ -0,0 +1,38 @@
+package looker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFilterByName(t *testing.T) {
+	names := []string{"tf_viewer", "Admin", "tf_analyst", "saml_tf_admin", "Developer"}
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected []string
+	}{
+		{name: "no filters", config: map[string]interface{}{}, expected: []string{"Admin", "Developer", "saml_tf_admin", "tf_analyst", "tf_viewer"}},
+		{name: "prefix", config: map[string]interface{}{"name_prefix": "tf_"}, expected: []string{"tf_analyst", "tf_viewer"}},
+		{name: "regex", config: map[string]interface{}{"name_regex": "tf_.*admin$"}, expected: []string{"saml_tf_admin"}},
+		{name: "prefix and regex", config: map[string]interface{}{"name_prefix": "tf_", "name_regex": "view"}, expected: []string{"tf_viewer"}},
+		{name: "no matches", config: map[string]interface{}{"name_prefix": "Tf_"}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, nameFilterSchema("roles"), tt.config)
+
+			filtered, err := filterByName(d, names, func(s string) string { return s })
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(filtered, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, filtered)
+			}
+		})
+	}
+}
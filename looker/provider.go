@@ -2,10 +2,15 @@ package looker
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/resolutionlife/terraform-provider-looker/version"
 	"gopkg.in/dnaeon/go-vcr.v3/recorder"
 
@@ -13,6 +18,23 @@ import (
 	client "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
+// lookerClient is the meta of the provider, which is shared by all resources and data sources.
+type lookerClient struct {
+	*client.LookerSDK
+
+	// locks serialises read-modify-write operations on Looker objects that are managed by more than one resource
+	locks *mutexKV
+
+	// workspace is the SDK of the API session of the project resources. They switch the workspace of the session to dev, so they use
+	// a session of their own, and the session of the other resources stays in the production workspace
+	workspace *client.LookerSDK
+
+	// settings, transport and tokens are used to create SDKs which make requests as other users
+	settings  rtl.ApiSettings
+	transport http.RoundTripper
+	tokens    *tokenCache
+}
+
 type ProviderOptions func(*schema.Provider)
 
 func WithRecorder(rec *recorder.Recorder) ProviderOptions {
@@ -27,52 +49,122 @@ func NewProvider(opts ...ProviderOptions) *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"base_url": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKERSDK_BASE_URL", nil),
 			},
 			"client_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKERSDK_CLIENT_ID", nil),
 			},
 			"client_secret": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKERSDK_CLIENT_SECRET", nil),
 			},
 			"verify_ssl": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("LOOKERSDK_VERIFY_SSL", true),
+				DefaultFunc: schema.EnvDefaultFunc("LOOKERSDK_VERIFY_SSL", nil),
 			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("LOOKERSDK_TIMEOUT", 120),
+				DefaultFunc: schema.EnvDefaultFunc("LOOKERSDK_TIMEOUT", nil),
+			},
+			"config_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a `looker.ini` file to read the settings of the provider from. Attributes set in the provider block and `LOOKERSDK_*` environment variables take precedence over the settings in the file",
+			},
+			"config_section": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Looker",
+				Description: "The section of the `looker.ini` file to read the settings of the provider from",
+			},
+			"run_as_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The id of the user to make all requests as, eg. to create content owned by the user. The provider logs in as the user with the `login_user` endpoint of the Looker API, so the client credentials must be of an admin",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a request is retried when the Looker API responds with a 429 status code, or with a 5xx status code to a `GET`, `HEAD`, `PUT` or `DELETE` request. Set to 0 to disable retries",
+			},
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The time in seconds to wait before the first retry. The wait time doubles with each retry",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum time in seconds to wait between retries, including any wait requested by the `Retry-After` header of the response",
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The maximum number of requests per second sent to the Looker API, shared by all resources. Set to 0 to not limit the rate of requests",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of requests to the Looker API that can be in flight at the same time, shared by all resources. Set to 0 to not limit the number of concurrent requests",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"looker_role":                  resourceRole(),
-			"looker_user":                  resourceUser(),
-			"looker_group":                 resourceGroup(),
-			"looker_user_roles":            resourceUserRoles(),
-			"looker_permission_set":        resourcePermissionSet(),
-			"looker_model_set":             resourceModelSet(),
-			"looker_group_user":            resourceGroupUser(),
-			"looker_group_group":           resourceGroupGroup(),
-			"looker_role_groups":           resourceRoleGroups(),
-			"looker_user_attribute":        resourceUserAttribute(),
-			"looker_user_attribute_user":   resourceUserAttributeUser(),
-			"looker_user_attribute_groups": resourceUserAttributeGroups(),
-			"looker_user_api_client":       resourceUserAPIClient(),
-			"looker_saml_config":           resourceSamlConfig(),
+			"looker_role":                   resourceRole(),
+			"looker_user":                   resourceUser(),
+			"looker_group":                  resourceGroup(),
+			"looker_user_roles":             resourceUserRoles(),
+			"looker_permission_set":         resourcePermissionSet(),
+			"looker_model_set":              resourceModelSet(),
+			"looker_group_user":             resourceGroupUser(),
+			"looker_group_group":            resourceGroupGroup(),
+			"looker_role_groups":            resourceRoleGroups(),
+			"looker_user_attribute":         resourceUserAttribute(),
+			"looker_user_attribute_user":    resourceUserAttributeUser(),
+			"looker_user_attribute_groups":  resourceUserAttributeGroups(),
+			"looker_user_api_client":        resourceUserAPIClient(),
+			"looker_ldap_config":            resourceLdapConfig(),
+			"looker_oidc_config":            resourceOidcConfig(),
+			"looker_saml_config":            resourceSamlConfig(),
+			"looker_folder":                 resourceFolder(),
+			"looker_folder_access":          resourceFolderAccess(),
+			"looker_dashboard":              resourceDashboard(),
+			"looker_look":                   resourceLook(),
+			"looker_scheduled_plan":         resourceScheduledPlan(),
+			"looker_datagroup_trigger":      resourceDatagroupTrigger(),
+			"looker_alert":                  resourceAlert(),
+			"looker_connection":             resourceConnection(),
+			"looker_project":                resourceProject(),
+			"looker_project_git_deploy_key": resourceProjectGitDeployKey(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"looker_role":           dataSourceRole(),
-			"looker_group":          dataSourceGroup(),
-			"looker_model_set":      dataSourceModelSet(),
-			"looker_permission_set": dataSourcePermissionSet(),
-			"looker_idp_metadata":   dataSourceLookerIdpMetadata(),
+			"looker_role":            dataSourceRole(),
+			"looker_roles":           dataSourceRoles(),
+			"looker_group":           dataSourceGroup(),
+			"looker_groups":          dataSourceGroups(),
+			"looker_model_set":       dataSourceModelSet(),
+			"looker_model_sets":      dataSourceModelSets(),
+			"looker_permission_set":  dataSourcePermissionSet(),
+			"looker_permission_sets": dataSourcePermissionSets(),
+			"looker_idp_metadata":    dataSourceLookerIdpMetadata(),
+			"looker_user":            dataSourceUser(),
+			"looker_users":           dataSourceUsers(),
+			"looker_datagroups":      dataSourceDatagroups(),
 		},
 		ConfigureContextFunc: configWrapper(nil),
 	}
@@ -86,23 +178,105 @@ func NewProvider(opts ...ProviderOptions) *schema.Provider {
 
 func configWrapper(rec *recorder.Recorder) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		apiSettings := rtl.ApiSettings{
-			BaseUrl:      d.Get("base_url").(string),
-			ClientId:     d.Get("client_id").(string),
-			ClientSecret: d.Get("client_secret").(string),
-			ApiVersion:   "4.0", // this provider only supports API version 4.0
-			VerifySsl:    d.Get("verify_ssl").(bool),
-			Timeout:      int32(d.Get("timeout").(int)),
-			AgentTag:     fmt.Sprintf("Terraform Looker Provider (%s)", version.ProviderVersion),
+		apiSettings, settingsErr := buildAPISettings(d)
+		if settingsErr != nil {
+			return nil, diag.FromErr(settingsErr)
 		}
 
-		var authSession *rtl.AuthSession
-		if rec == nil {
-			authSession = rtl.NewAuthSession(apiSettings)
-		} else {
-			authSession = rtl.NewAuthSessionWithTransport(apiSettings, rec)
+		// this matches the transport built by rtl.NewAuthSession
+		var transport http.RoundTripper = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !apiSettings.VerifySsl, //nolint:gosec
+			},
+		}
+		if rec != nil {
+			transport = rec
 		}
 
-		return client.NewLookerSDK(authSession), nil
+		// every attempt of a retried request counts towards the rate limit, so the rate limit is applied below the retries
+		transport = newRateLimitTransport(ctx, transport,
+			d.Get("max_requests_per_second").(float64),
+			d.Get("max_concurrent_requests").(int),
+		)
+		transport = newRetryTransport(ctx, transport,
+			d.Get("max_retries").(int),
+			time.Duration(d.Get("retry_min_wait").(int))*time.Second,
+			time.Duration(d.Get("retry_max_wait").(int))*time.Second,
+		)
+
+		// the admin SDK authenticates with the client credentials, and is used to login as other users
+		admin := client.NewLookerSDK(rtl.NewAuthSessionWithTransport(apiSettings, transport))
+		tokens := newTokenCache(admin)
+
+		api := admin
+		workspace := client.NewLookerSDK(rtl.NewAuthSessionWithTransport(apiSettings, transport))
+		if userID, ok := d.GetOk("run_as_user_id"); ok {
+			api = newSudoSDK(apiSettings, transport, tokens, userID.(string))
+			// a token of the user is a session of its own, so the workspace session has a separate token cache
+			workspace = newSudoSDK(apiSettings, transport, newTokenCache(admin), userID.(string))
+		}
+
+		return &lookerClient{
+			LookerSDK: api,
+			locks:     newMutexKV(),
+			workspace: workspace,
+			settings:  apiSettings,
+			transport: transport,
+			tokens:    tokens,
+		}, nil
+	}
+}
+
+// buildAPISettings builds the settings of the Looker SDK. Each setting is taken from, in order of precedence:
+//  1. the attribute in the provider block
+//  2. the LOOKERSDK_* environment variable
+//  3. the config_section of the ini file at config_path, if config_path is set
+//  4. the default value of the setting
+func buildAPISettings(d *schema.ResourceData) (rtl.ApiSettings, error) {
+	settings := rtl.ApiSettings{
+		VerifySsl: true,
+		Timeout:   120,
+	}
+
+	if path, ok := d.GetOk("config_path"); ok {
+		section := d.Get("config_section").(string)
+
+		fileSettings, fileErr := rtl.NewSettingsFromFile(path.(string), &section)
+		if fileErr != nil {
+			return rtl.ApiSettings{}, fmt.Errorf("failed to read section %s of config file %s: %w", section, path, fileErr)
+		}
+		settings = fileSettings
 	}
+
+	// attributes which are not set in the provider block default to the value of the environment variable
+	if v, ok := d.GetOk("base_url"); ok {
+		settings.BaseUrl = v.(string)
+	}
+	if v, ok := d.GetOk("client_id"); ok {
+		settings.ClientId = v.(string)
+	}
+	if v, ok := d.GetOk("client_secret"); ok {
+		settings.ClientSecret = v.(string)
+	}
+	if v, ok := d.GetOkExists("verify_ssl"); ok { //nolint:staticcheck // GetOk cannot tell if verify_ssl has been set to false
+		settings.VerifySsl = v.(bool)
+	}
+	if v, ok := d.GetOk("timeout"); ok {
+		settings.Timeout = int32(v.(int))
+	}
+
+	if settings.BaseUrl == "" {
+		return rtl.ApiSettings{}, errors.New("base_url must be set in the provider block, with the LOOKERSDK_BASE_URL environment variable or in the config file")
+	}
+	if settings.ClientId == "" {
+		return rtl.ApiSettings{}, errors.New("client_id must be set in the provider block, with the LOOKERSDK_CLIENT_ID environment variable or in the config file")
+	}
+	if settings.ClientSecret == "" {
+		return rtl.ApiSettings{}, errors.New("client_secret must be set in the provider block, with the LOOKERSDK_CLIENT_SECRET environment variable or in the config file")
+	}
+
+	settings.ApiVersion = "4.0" // this provider only supports API version 4.0
+	settings.AgentTag = fmt.Sprintf("Terraform Looker Provider (%s)", version.ProviderVersion)
+
+	return settings, nil
 }
@@ -174,3 +174,100 @@ func customMatcher(r *http.Request, i cassette.Request) bool {
 
 	return string(reqBody) == i.Body
 }
+
+func TestBuildAPISettings(t *testing.T) {
+	configPath := path.Join(t.TempDir(), "looker.ini")
+	ini := `[Looker]
+base_url=https://file.looker.com
+client_id=file-client-id
+client_secret=file-client-secret
+verify_ssl=false
+timeout=60
+
+[Staging]
+base_url=https://staging.looker.com
+client_id=staging-client-id
+client_secret=staging-client-secret
+`
+	if err := os.WriteFile(configPath, []byte(ini), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		env     map[string]string
+		want    rtl.ApiSettings
+		wantErr bool
+	}{
+		{
+			name: "attributes",
+			config: map[string]interface{}{
+				"base_url":      "https://attr.looker.com",
+				"client_id":     "attr-client-id",
+				"client_secret": "attr-client-secret",
+			},
+			want: rtl.ApiSettings{BaseUrl: "https://attr.looker.com", ClientId: "attr-client-id", ClientSecret: "attr-client-secret", VerifySsl: true, Timeout: 120},
+		},
+		{
+			name:   "config file",
+			config: map[string]interface{}{"config_path": configPath},
+			want:   rtl.ApiSettings{BaseUrl: "https://file.looker.com", ClientId: "file-client-id", ClientSecret: "file-client-secret", VerifySsl: false, Timeout: 60},
+		},
+		{
+			name:   "config file section",
+			config: map[string]interface{}{"config_path": configPath, "config_section": "Staging"},
+			want:   rtl.ApiSettings{BaseUrl: "https://staging.looker.com", ClientId: "staging-client-id", ClientSecret: "staging-client-secret", VerifySsl: true, Timeout: 120},
+		},
+		{
+			name:   "environment variables take precedence over the config file",
+			config: map[string]interface{}{"config_path": configPath},
+			env:    map[string]string{"LOOKERSDK_CLIENT_ID": "env-client-id", "LOOKERSDK_VERIFY_SSL": "true"},
+			want:   rtl.ApiSettings{BaseUrl: "https://file.looker.com", ClientId: "env-client-id", ClientSecret: "file-client-secret", VerifySsl: true, Timeout: 60},
+		},
+		{
+			name:   "attributes take precedence over environment variables and the config file",
+			config: map[string]interface{}{"config_path": configPath, "client_id": "attr-client-id", "timeout": 30},
+			env:    map[string]string{"LOOKERSDK_CLIENT_ID": "env-client-id"},
+			want:   rtl.ApiSettings{BaseUrl: "https://file.looker.com", ClientId: "attr-client-id", ClientSecret: "file-client-secret", VerifySsl: false, Timeout: 30},
+		},
+		{
+			name:    "missing base url",
+			config:  map[string]interface{}{"client_id": "attr-client-id", "client_secret": "attr-client-secret"},
+			wantErr: true,
+		},
+		{
+			name:    "missing config file",
+			config:  map[string]interface{}{"config_path": path.Join(t.TempDir(), "missing.ini")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"LOOKERSDK_BASE_URL", "LOOKERSDK_CLIENT_ID", "LOOKERSDK_CLIENT_SECRET", "LOOKERSDK_VERIFY_SSL", "LOOKERSDK_TIMEOUT"} {
+				t.Setenv(k, tt.env[k])
+			}
+
+			d := schema.TestResourceDataRaw(t, NewProvider().Schema, tt.config)
+			got, err := buildAPISettings(d)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.BaseUrl != tt.want.BaseUrl || got.ClientId != tt.want.ClientId || got.ClientSecret != tt.want.ClientSecret ||
+				got.VerifySsl != tt.want.VerifySsl || got.Timeout != tt.want.Timeout {
+				t.Errorf("expected settings %+v, got %+v", tt.want, got)
+			}
+			if got.ApiVersion != "4.0" {
+				t.Errorf("expected api version 4.0, got %s", got.ApiVersion)
+			}
+		})
+	}
+}
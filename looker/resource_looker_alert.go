@@ -0,0 +1,317 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+var (
+	alertAttrs = []string{"dashboard_element_id", "comparison_type", "threshold", "cron", "owner_id", "is_disabled", "disabled_reason", "is_public"}
+
+	// alertPatchAttrs are the attributes of an alert which can be updated without replacing the whole alert, which is how the owner of
+	// an alert is changed.
+	alertPatchAttrs = []string{"owner_id", "is_disabled", "disabled_reason", "is_public", "threshold"}
+
+	alertComparisonTypes = []string{
+		string(sdk.ComparisonType_EQUAL_TO),
+		string(sdk.ComparisonType_GREATER_THAN),
+		string(sdk.ComparisonType_GREATER_THAN_OR_EQUAL_TO),
+		string(sdk.ComparisonType_LESS_THAN),
+		string(sdk.ComparisonType_LESS_THAN_OR_EQUAL_TO),
+		string(sdk.ComparisonType_INCREASES_BY),
+		string(sdk.ComparisonType_DECREASES_BY),
+		string(sdk.ComparisonType_CHANGES_BY),
+	}
+	alertDestinationTypes = []string{string(sdk.DestinationType_EMAIL), string(sdk.DestinationType_ACTION_HUB)}
+)
+
+func resourceAlert() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates an alert on a tile of a dashboard in a Looker instance, which notifies its destinations when a field of the query of the tile crosses a threshold. " +
+			"The owner of the alert can be changed without replacing it, eg. when its owner leaves the organisation.",
+
+		CreateContext: resourceAlertCreate,
+		ReadContext:   resourceAlertRead,
+		UpdateContext: resourceAlertUpdate,
+		DeleteContext: resourceAlertDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dashboard_element_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the dashboard element, ie. the tile, which the alert is set on",
+			},
+			"field": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The field of the query of the dashboard element which is compared with the threshold",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the field, eg. `orders.count`",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The title of the field, as it is shown in the alert",
+						},
+					},
+				},
+			},
+			"comparison_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(alertComparisonTypes, false),
+				Description:  fmt.Sprintf("How the field is compared with the threshold, one of `%s`", strings.Join(alertComparisonTypes, "`, `")),
+			},
+			"threshold": {
+				Type:        schema.TypeFloat,
+				Required:    true,
+				Description: "The value the field is compared with",
+			},
+			"cron": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The schedule on which the alert is checked as a crontab expression, eg. `0 7 * * *`",
+			},
+			"destination": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The destinations which are notified when the alert is triggered",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(alertDestinationTypes, false),
+							Description:  fmt.Sprintf("The type of the destination, one of `%s`", strings.Join(alertDestinationTypes, "`, `")),
+						},
+						"email_address": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The email address which is notified, for `EMAIL` destinations",
+						},
+						"action_hub_integration_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The id of the Action Hub integration which is notified, eg. a Slack integration, for `ACTION_HUB` destinations",
+						},
+						"action_hub_form_params_json": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "The parameters of the form of the Action Hub integration as JSON, for `ACTION_HUB` destinations",
+						},
+					},
+				},
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The id of the user who owns the alert. The alert is owned by the user the provider is authenticated as if it is not set. Changing the owner updates the alert in place",
+			},
+			"is_disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the alert is disabled",
+			},
+			"disabled_reason": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The reason the alert is disabled, which Looker requires when `is_disabled` is set",
+			},
+			"is_public": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the alert can be seen and followed by the other users who can see the dashboard",
+			},
+			"custom_title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The title of the notifications of the alert",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the alert",
+			},
+		},
+	}
+}
+
+func resourceAlertCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	ownerID := d.Get("owner_id").(string)
+	if ownerID == "" {
+		me, meErr := api.Me("id", nil)
+		if meErr != nil {
+			return apiDiags(meErr, "failed to get the current user to own the alert")
+		}
+		ownerID = conv.Deref(me.Id)
+	}
+
+	alert, createErr := api.CreateAlert(expandAlert(d, ownerID), nil)
+	if createErr != nil {
+		return apiDiags(createErr, "failed to create alert", alertAttrs...)
+	}
+
+	if alert.Id == nil {
+		return diag.Errorf("alert has missing id")
+	}
+	d.SetId(*alert.Id)
+
+	return resourceAlertRead(ctx, d, c)
+}
+
+func resourceAlertRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	alert, alertErr := api.GetAlert(d.Id(), nil)
+	if errors.Is(alertErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if alertErr != nil {
+		return apiDiags(alertErr, "failed to read alert")
+	}
+
+	result := multierror.Append(
+		d.Set("dashboard_element_id", alert.DashboardElementId),
+		d.Set("field", []interface{}{map[string]interface{}{"name": alert.Field.Name, "title": alert.Field.Title}}),
+		d.Set("comparison_type", string(alert.ComparisonType)),
+		d.Set("threshold", alert.Threshold),
+		d.Set("cron", alert.Cron),
+		d.Set("destination", flattenAlertDestinations(alert.Destinations)),
+		d.Set("owner_id", alert.OwnerId),
+		d.Set("is_disabled", alert.IsDisabled),
+		d.Set("disabled_reason", alert.DisabledReason),
+		d.Set("is_public", alert.IsPublic),
+		d.Set("custom_title", alert.CustomTitle),
+		d.Set("description", alert.Description),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceAlertUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// the whole alert is replaced if any attribute which cannot be patched has changed, otherwise only the changed attributes are patched
+	if d.HasChangesExcept(alertPatchAttrs...) {
+		if _, updateErr := api.UpdateAlert(d.Id(), expandAlert(d, d.Get("owner_id").(string)), nil); updateErr != nil {
+			return apiDiags(updateErr, "failed to update alert", alertAttrs...)
+		}
+
+		return resourceAlertRead(ctx, d, c)
+	}
+
+	var patch sdk.AlertPatch
+	if d.HasChange("owner_id") {
+		patch.OwnerId = conv.P(d.Get("owner_id").(string))
+	}
+	if d.HasChange("is_disabled") {
+		patch.IsDisabled = conv.P(d.Get("is_disabled").(bool))
+	}
+	if d.HasChange("disabled_reason") {
+		patch.DisabledReason = conv.P(d.Get("disabled_reason").(string))
+	}
+	if d.HasChange("is_public") {
+		patch.IsPublic = conv.P(d.Get("is_public").(bool))
+	}
+	if d.HasChange("threshold") {
+		patch.Threshold = conv.P(d.Get("threshold").(float64))
+	}
+
+	if _, patchErr := api.UpdateAlertField(d.Id(), patch, nil); patchErr != nil {
+		return apiDiags(patchErr, "failed to update alert", alertAttrs...)
+	}
+
+	return resourceAlertRead(ctx, d, c)
+}
+
+func resourceAlertDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	delErr := api.DeleteAlert(d.Id(), nil)
+	if !errors.Is(delErr, sdk.ErrNotFound) {
+		return apiDiags(delErr, "failed to delete alert")
+	}
+
+	return nil
+}
+
+// expandAlert maps the attributes of the resource to the body of an alert owned by ownerID in the Looker API.
+func expandAlert(d *schema.ResourceData, ownerID string) sdk.WriteAlert {
+	field := d.Get("field").([]interface{})[0].(map[string]interface{})
+
+	return sdk.WriteAlert{
+		DashboardElementId: conv.P(d.Get("dashboard_element_id").(string)),
+		Field: sdk.AlertField{
+			Name:  field["name"].(string),
+			Title: field["title"].(string),
+		},
+		ComparisonType: sdk.ComparisonType(d.Get("comparison_type").(string)),
+		Threshold:      d.Get("threshold").(float64),
+		Cron:           d.Get("cron").(string),
+		Destinations:   expandAlertDestinations(d.Get("destination").([]interface{})),
+		OwnerId:        ownerID,
+		IsDisabled:     conv.P(d.Get("is_disabled").(bool)),
+		DisabledReason: conv.PString(d.Get("disabled_reason").(string)),
+		IsPublic:       conv.P(d.Get("is_public").(bool)),
+		CustomTitle:    conv.PString(d.Get("custom_title").(string)),
+		Description:    conv.PString(d.Get("description").(string)),
+	}
+}
+
+func expandAlertDestinations(vs []interface{}) []sdk.AlertDestination {
+	destinations := make([]sdk.AlertDestination, len(vs))
+	for i, v := range vs {
+		dest := v.(map[string]interface{})
+		destinations[i] = sdk.AlertDestination{
+			DestinationType:         sdk.DestinationType(dest["type"].(string)),
+			EmailAddress:            conv.PString(dest["email_address"].(string)),
+			ActionHubIntegrationId:  conv.PString(dest["action_hub_integration_id"].(string)),
+			ActionHubFormParamsJson: conv.PString(dest["action_hub_form_params_json"].(string)),
+		}
+	}
+
+	return destinations
+}
+
+// flattenAlertDestinations takes the destinations of an alert and maps them to the destination blocks of the resource. This function
+// returns a []interface{} because the terraform aggregate type schema.TypeList expects a slice of attributes.
+func flattenAlertDestinations(destinations []sdk.AlertDestination) []interface{} {
+	flattened := make([]interface{}, len(destinations))
+	for i, dest := range destinations {
+		flattened[i] = map[string]interface{}{
+			"type":                        string(dest.DestinationType),
+			"email_address":               conv.Deref(dest.EmailAddress),
+			"action_hub_integration_id":   conv.Deref(dest.ActionHubIntegrationId),
+			"action_hub_form_params_json": conv.Deref(dest.ActionHubFormParamsJson),
+		}
+	}
+
+	return flattened
+}
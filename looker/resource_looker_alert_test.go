@@ -0,0 +1,108 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func TestAccLookerAlert(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_alert")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the alert is owned by the user the provider is authenticated as
+				Config: testAccAlertConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_alert.test_acc", "id", "19"),
+					resource.TestCheckResourceAttr("looker_alert.test_acc", "owner_id", "12"),
+					resource.TestCheckResourceAttr("looker_alert.test_acc", "field.0.name", "orders.count"),
+					resource.TestCheckResourceAttr("looker_alert.test_acc", "threshold", "1000"),
+					resource.TestCheckResourceAttr("looker_alert.test_acc", "destination.0.email_address", "analytics@example.com"),
+				),
+			},
+			{
+				// the ownership of the alert is transferred in place
+				Config: testAccAlertConfig(`owner_id = "27"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_alert.test_acc", "id", "19"),
+					resource.TestCheckResourceAttr("looker_alert.test_acc", "owner_id", "27"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAlertConfig(owner string) string {
+	return fmt.Sprintf(`
+	resource "looker_alert" "test_acc" {
+		dashboard_element_id = "46"
+		comparison_type      = "GREATER_THAN"
+		threshold            = 1000
+		cron                 = "0 7 * * *"
+		is_public            = true
+		description          = "Daily orders above target"
+		%s
+
+		field {
+			name  = "orders.count"
+			title = "Orders Count"
+		}
+
+		destination {
+			type          = "EMAIL"
+			email_address = "analytics@example.com"
+		}
+	}
+	`, owner)
+}
+
+func testAccCheckAlertDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "looker_alert" {
+			continue
+		}
+
+		_, err := client.GetAlert(r.Primary.ID, nil)
+		if errors.Is(err, sdk.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("alert %s has not been deleted", r.Primary.ID)
+	}
+
+	return nil
+}
+
+func TestAlertDestinations(t *testing.T) {
+	destinations := []interface{}{
+		map[string]interface{}{"type": "EMAIL", "email_address": "analytics@example.com", "action_hub_integration_id": "", "action_hub_form_params_json": ""},
+		map[string]interface{}{"type": "ACTION_HUB", "email_address": "", "action_hub_integration_id": "1::slack_app", "action_hub_form_params_json": `{"channel":"#orders"}`},
+	}
+
+	expanded := expandAlertDestinations(destinations)
+	if expanded[0].ActionHubIntegrationId != nil || expanded[1].EmailAddress != nil {
+		t.Errorf("expected the unset attributes of the destinations to be omitted, got %+v", expanded)
+	}
+	if expanded[1].DestinationType != sdk.DestinationType_ACTION_HUB {
+		t.Errorf("expected an ACTION_HUB destination, got %s", expanded[1].DestinationType)
+	}
+
+	if got := flattenAlertDestinations(expanded); !reflect.DeepEqual(got, destinations) {
+		t.Errorf("expected %v, got %v", destinations, got)
+	}
+}
@@ -0,0 +1,317 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+// connectionAttrs are the attributes of looker_connection which are sent in the body of a connection, and can be reported by the
+// validation errors of the Looker API.
+var connectionAttrs = []string{
+	"name", "host", "port", "database", "schema", "username", "password", "certificate", "file_type", "ssl", "verify_ssl", "max_connections",
+	"tmp_db_name", "pdt_concurrency", "maintenance_cron",
+}
+
+func resourceConnection() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates a database connection in a Looker instance. Note that the `password` and `certificate` of a connection cannot be read from the Looker API, so the provider cannot detect if they have been changed in the Looker UI.",
+
+		CreateContext: resourceConnectionCreate,
+		ReadContext:   resourceConnectionRead,
+		UpdateContext: resourceConnectionUpdate,
+		DeleteContext: resourceConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the connection. This is how the connection is referenced in LookML",
+			},
+			"dialect": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the SQL dialect of the database, eg. `bigquery_standard_sql`, `snowflake` or `postgres`",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The host name or IP address of the database server. For BigQuery this is the project id",
+			},
+			"port": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The port of the database server. Defaults to the standard port of the dialect",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the database. For BigQuery this is the dataset",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default schema of the connection",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The username used to connect to the database. For BigQuery this is the service account email",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password used to connect to the database. This value is write only and cannot be read from the Looker API",
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The base64 encoded certificate used to connect to the database, eg. a BigQuery service account key. This value is write only and cannot be read from the Looker API",
+			},
+			"file_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file type of the certificate, either `.json` or `.p12`",
+			},
+			"ssl": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use SSL/TLS when connecting to the database",
+			},
+			"verify_ssl": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Verify the SSL certificate of the database server",
+			},
+			"max_connections": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of concurrent connections Looker can open to the database",
+			},
+			"tmp_db_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the scratch schema used to build persistent derived tables (PDTs). PDTs are disabled if this is not set",
+			},
+			"pdt_concurrency": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of persistent derived tables (PDTs) that can be built concurrently",
+			},
+			"maintenance_cron": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The cron schedule used to check datagroup triggers and rebuild persistent derived tables (PDTs)",
+			},
+			"pdts_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether persistent derived tables (PDTs) are enabled on the connection",
+			},
+			"test_on_apply": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, the connection is tested when it is created or updated, and the apply fails if any of the tests fail",
+			},
+		},
+	}
+}
+
+func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	name := d.Get("name").(string)
+	connection := buildConnectionInput(d)
+	connection.Name = conv.PString(name)
+	connection.Password = conv.PString(d.Get("password").(string))
+	connection.Certificate = conv.PString(d.Get("certificate").(string))
+
+	conn, connErr := api.CreateConnection(connection, nil)
+	if connErr != nil {
+		return apiDiags(connErr, "failed to create connection "+name, connectionAttrs...)
+	}
+
+	if conn.Name == nil {
+		return diag.Errorf("connection %s has missing name", name)
+	}
+	d.SetId(*conn.Name)
+
+	if d.Get("test_on_apply").(bool) {
+		if diags := testConnection(api, d.Id()); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceConnectionRead(ctx, d, c)
+}
+
+func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	conn, connErr := api.Connection(d.Id(), "", nil)
+	if errors.Is(connErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if connErr != nil {
+		return apiDiags(connErr, "failed to read connection "+d.Id())
+	}
+
+	// the password and certificate cannot be retrieved from the API, so the values in the state are kept
+	result := multierror.Append(
+		d.Set("name", conn.Name),
+		d.Set("dialect", conn.DialectName),
+		d.Set("host", conn.Host),
+		d.Set("port", conn.Port),
+		d.Set("database", conn.Database),
+		d.Set("schema", conn.Schema),
+		d.Set("username", conn.Username),
+		d.Set("file_type", conn.FileType),
+		d.Set("ssl", conn.Ssl),
+		d.Set("verify_ssl", conn.VerifySsl),
+		d.Set("max_connections", conn.MaxConnections),
+		d.Set("tmp_db_name", conn.TmpDbName),
+		d.Set("pdt_concurrency", conn.PdtConcurrency),
+		d.Set("maintenance_cron", conn.MaintenanceCron),
+		d.Set("pdts_enabled", conn.PdtsEnabled),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	connection := buildConnectionInput(d)
+
+	// secrets are only sent when they have changed, otherwise looker keeps the existing value
+	if d.HasChange("password") {
+		connection.Password = conv.P(d.Get("password").(string))
+	}
+	if d.HasChange("certificate") {
+		connection.Certificate = conv.P(d.Get("certificate").(string))
+	}
+
+	// fields which are removed from the config are cleared, as they are omitted from the body of the request when they are empty
+	if d.HasChange("host") {
+		connection.Host = conv.P(d.Get("host").(string))
+	}
+	if d.HasChange("database") {
+		connection.Database = conv.P(d.Get("database").(string))
+	}
+	if d.HasChange("schema") {
+		connection.Schema = conv.P(d.Get("schema").(string))
+	}
+	if d.HasChange("username") {
+		connection.Username = conv.P(d.Get("username").(string))
+	}
+	if d.HasChange("file_type") {
+		connection.FileType = conv.P(d.Get("file_type").(string))
+	}
+	if d.HasChange("tmp_db_name") {
+		connection.TmpDbName = conv.P(d.Get("tmp_db_name").(string))
+	}
+	if d.HasChange("maintenance_cron") {
+		connection.MaintenanceCron = conv.P(d.Get("maintenance_cron").(string))
+	}
+
+	_, connErr := api.UpdateConnection(d.Id(), connection, nil)
+	if connErr != nil {
+		return apiDiags(connErr, "failed to update connection "+d.Id(), connectionAttrs...)
+	}
+
+	if d.Get("test_on_apply").(bool) {
+		if diags := testConnection(api, d.Id()); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceConnectionRead(ctx, d, c)
+}
+
+func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	_, delErr := api.DeleteConnection(d.Id(), nil)
+	if !errors.Is(delErr, sdk.ErrNotFound) {
+		return apiDiags(delErr, "failed to delete connection "+d.Id())
+	}
+
+	return nil
+}
+
+// buildConnectionInput builds the connection from the resource data, excluding the name and secrets of the connection.
+func buildConnectionInput(d *schema.ResourceData) sdk.WriteDBConnection {
+	connection := sdk.WriteDBConnection{
+		DialectName:     conv.PString(d.Get("dialect").(string)),
+		Host:            conv.PString(d.Get("host").(string)),
+		Port:            conv.PString(d.Get("port").(string)),
+		Database:        conv.PString(d.Get("database").(string)),
+		Schema:          conv.PString(d.Get("schema").(string)),
+		Username:        conv.PString(d.Get("username").(string)),
+		FileType:        conv.PString(d.Get("file_type").(string)),
+		Ssl:             conv.PBool(d.Get("ssl").(bool)),
+		VerifySsl:       conv.PBool(d.Get("verify_ssl").(bool)),
+		TmpDbName:       conv.PString(d.Get("tmp_db_name").(string)),
+		MaintenanceCron: conv.PString(d.Get("maintenance_cron").(string)),
+	}
+
+	if v, ok := d.GetOk("max_connections"); ok {
+		connection.MaxConnections = conv.P(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("pdt_concurrency"); ok {
+		connection.PdtConcurrency = conv.P(int64(v.(int)))
+	}
+
+	return connection
+}
+
+// testConnection runs all the tests supported by the dialect of the connection, and returns an error diagnostic for each failed test.
+func testConnection(api *sdk.LookerSDK, name string) diag.Diagnostics {
+	results, testErr := api.TestConnection(name, nil, nil)
+	if testErr != nil {
+		return apiDiags(testErr, "failed to test connection "+name)
+	}
+
+	var diags diag.Diagnostics
+	for _, r := range results {
+		if r.Status == nil || *r.Status != "error" {
+			continue
+		}
+
+		var testName, message string
+		if r.Name != nil {
+			testName = *r.Name
+		}
+		if r.Message != nil {
+			message = *r.Message
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("connection test %q failed", testName),
+			Detail:   fmt.Sprintf("the %s test for connection %s failed: %s", testName, name, message),
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,155 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func init() {
+	// Add a sweeper to remove connections that have names starting with `test-acc`.
+	resource.AddTestSweepers("looker_connection", &resource.Sweeper{
+		Name: "looker_connection",
+		F: func(_ string) error {
+			c, err := newTestLookerSDK()
+			if err != nil {
+				return err
+			}
+
+			connections, err := c.AllConnections("name", nil)
+			if err != nil {
+				return err
+			}
+
+			for _, conn := range connections {
+				if conn.Name == nil || !strings.HasPrefix(*conn.Name, "test-acc") {
+					continue
+				}
+				if _, err := c.DeleteConnection(*conn.Name, nil); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
+
+func TestAccLookerConnection(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_connection")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "looker_connection" "test_acc" {
+					name            = "test-acc-connection"
+					dialect         = "postgres"
+					host            = "db.example.com"
+					port            = "5432"
+					database        = "analytics"
+					schema          = "public"
+					username        = "looker"
+					password        = "test-acc-password"
+					ssl             = true
+					tmp_db_name     = "looker_scratch"
+					max_connections = 10
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_connection.test_acc", "id", "test-acc-connection"),
+					resource.TestCheckResourceAttr("looker_connection.test_acc", "dialect", "postgres"),
+					resource.TestCheckResourceAttr("looker_connection.test_acc", "max_connections", "10"),
+					resource.TestCheckResourceAttr("looker_connection.test_acc", "password", "test-acc-password"),
+					resource.TestCheckResourceAttr("looker_connection.test_acc", "pdts_enabled", "true"),
+				),
+			},
+			{
+				Config: `
+				resource "looker_connection" "test_acc" {
+					name            = "test-acc-connection"
+					dialect         = "postgres"
+					host            = "db.example.com"
+					port            = "5432"
+					database        = "analytics"
+					schema          = "public"
+					username        = "looker"
+					password        = "test-acc-password"
+					ssl             = true
+					tmp_db_name     = "looker_scratch"
+					max_connections = 20
+					test_on_apply   = true
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_connection.test_acc", "max_connections", "20"),
+					resource.TestCheckResourceAttr("looker_connection.test_acc", "password", "test-acc-password"),
+				),
+			},
+		},
+	})
+}
+
+func TestConnectionUpdateClearsRemovedFields(t *testing.T) {
+	var written map[string]interface{}
+	c := newFakeLookerServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodPatch:
+			if err := json.NewDecoder(r.Body).Decode(&written); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, "{}")
+		case http.MethodGet:
+			fmt.Fprint(w, `{"name":"test-acc-connection","dialect_name":"postgres","host":"db.example.com"}`)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+
+	r := resourceConnection()
+	state := &terraform.InstanceState{
+		ID: "test-acc-connection",
+		Attributes: map[string]string{
+			"id":          "test-acc-connection",
+			"name":        "test-acc-connection",
+			"dialect":     "postgres",
+			"host":        "db.example.com",
+			"schema":      "public",
+			"tmp_db_name": "looker_scratch",
+		},
+	}
+	// schema and tmp_db_name are removed from the config
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":    "test-acc-connection",
+		"dialect": "postgres",
+		"host":    "db.example.com",
+	})
+
+	diff, diffErr := r.Diff(context.Background(), state, config, c)
+	if diffErr != nil {
+		t.Fatalf("unexpected error: %v", diffErr)
+	}
+	if _, diags := r.Apply(context.Background(), state, diff, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for _, k := range []string{"schema", "tmp_db_name"} {
+		if v, ok := written[k]; !ok || v != "" {
+			t.Errorf("expected %s to be cleared, got %v", k, written)
+		}
+	}
+	if _, ok := written["database"]; ok {
+		t.Errorf("expected the unchanged empty database to be omitted, got %v", written)
+	}
+}
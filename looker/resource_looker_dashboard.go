@@ -0,0 +1,421 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+// dashboardPayload is the JSON definition of a dashboard, which is the writable fields of a dashboard with its elements and filters.
+type dashboardPayload struct {
+	sdk.WriteDashboard
+	DashboardElements []sdk.WriteDashboardElement      `json:"dashboard_elements,omitempty"`
+	DashboardFilters  []sdk.WriteCreateDashboardFilter `json:"dashboard_filters,omitempty"`
+}
+
+func resourceDashboard() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates a user-defined dashboard in a Looker instance from its LookML, as exported by the `Download LookML` option of a dashboard, or from a JSON definition, which is the body of a dashboard in the Looker API with its `dashboard_elements` and `dashboard_filters`. " +
+			"Only the fields which are set in the definition are compared with the dashboard in Looker, and volatile fields such as ids and timestamps are ignored, so that the same definition can be applied to several instances. " +
+			"Looker cannot update a dashboard from LookML, so the dashboard is replaced when `lookml` changes.",
+
+		CreateContext: resourceDashboardCreate,
+		ReadContext:   resourceDashboardRead,
+		UpdateContext: resourceDashboardUpdate,
+		DeleteContext: resourceDashboardDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"folder_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the folder of the dashboard",
+			},
+			"lookml": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"lookml", "json"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateContentLookml),
+				DiffSuppressFunc: suppressEquivalentContent(parseContentLookml),
+				Description:      "The LookML of the dashboard",
+			},
+			"json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"lookml", "json"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				DiffSuppressFunc: suppressEquivalentContent(parseContentJSON),
+				Description:      "The JSON definition of the dashboard. The elements of the dashboard are matched by their title, or otherwise by their position, and are updated in place so that they keep their ids. The filters of the dashboard are replaced when they change",
+			},
+			"run_as_user_id": runAsSchema(),
+			"title": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The title of the dashboard",
+			},
+			"slug": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The slug of the dashboard, which can be used in the url of the dashboard instead of its id",
+			},
+			"content_metadata_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the content metadata of the dashboard, used to manage access to the dashboard",
+			},
+		},
+	}
+}
+
+func resourceDashboardCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	folderID := d.Get("folder_id").(string)
+
+	if lookml := d.Get("lookml").(string); lookml != "" {
+		dashboard, createErr := api.CreateDashboardFromLookml(sdk.WriteDashboardLookml{
+			FolderId: conv.P(folderID),
+			Lookml:   conv.P(lookml),
+		}, nil)
+		if createErr != nil {
+			return apiDiags(createErr, "failed to create dashboard from LookML", "lookml")
+		}
+
+		if dashboard.Id == nil {
+			return diag.Errorf("dashboard has missing id")
+		}
+		d.SetId(*dashboard.Id)
+
+		return resourceDashboardRead(ctx, d, c)
+	}
+
+	payload, payloadErr := expandDashboardPayload(d.Get("json").(string))
+	if payloadErr != nil {
+		return diag.FromErr(payloadErr)
+	}
+
+	body := payload.WriteDashboard
+	body.FolderId = conv.P(folderID)
+
+	dashboard, createErr := api.CreateDashboard(body, nil)
+	if createErr != nil {
+		return apiDiags(createErr, "failed to create dashboard", "folder_id")
+	}
+
+	if dashboard.Id == nil {
+		return diag.Errorf("dashboard has missing id")
+	}
+	d.SetId(*dashboard.Id)
+
+	// the filters are created before the elements, so that the elements can listen to them
+	if diags := createDashboardFilters(api, d.Id(), payload.DashboardFilters); diags.HasError() {
+		return diags
+	}
+	if diags := createDashboardElements(api, d.Id(), payload.DashboardElements); diags.HasError() {
+		return diags
+	}
+
+	return resourceDashboardRead(ctx, d, c)
+}
+
+func resourceDashboardRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	dashboard, dashboardErr := api.Dashboard(d.Id(), "", nil)
+	if errors.Is(dashboardErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if dashboardErr != nil {
+		return apiDiags(dashboardErr, "failed to read dashboard")
+	}
+
+	// a deleted dashboard is kept in the trash of the instance, from where it can be restored
+	if conv.Deref(dashboard.Deleted) {
+		d.SetId("")
+		return nil
+	}
+
+	result := multierror.Append(
+		d.Set("folder_id", dashboard.FolderId),
+		d.Set("title", dashboard.Title),
+		d.Set("slug", dashboard.Slug),
+		d.Set("content_metadata_id", dashboard.ContentMetadataId),
+	)
+
+	if d.Get("lookml").(string) != "" {
+		lookml, lookmlErr := api.DashboardLookml(d.Id(), nil)
+		if lookmlErr != nil {
+			return apiDiags(lookmlErr, "failed to read the LookML of the dashboard")
+		}
+
+		result = multierror.Append(result, d.Set("lookml", lookml.Lookml))
+	} else {
+		// an imported dashboard is read as JSON
+		serialized, serializeErr := serializeContent(dashboard)
+		if serializeErr != nil {
+			return diag.FromErr(serializeErr)
+		}
+
+		result = multierror.Append(result, d.Set("json", serialized))
+	}
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceDashboardUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	// a dashboard created from LookML can only be moved, as changes to its LookML replace it
+	if d.Get("lookml").(string) != "" {
+		if d.HasChange("folder_id") {
+			_, updateErr := api.UpdateDashboard(d.Id(), sdk.WriteDashboard{FolderId: conv.P(d.Get("folder_id").(string))}, nil)
+			if updateErr != nil {
+				return apiDiags(updateErr, "failed to move dashboard", "folder_id")
+			}
+		}
+
+		return resourceDashboardRead(ctx, d, c)
+	}
+
+	payload, payloadErr := expandDashboardPayload(d.Get("json").(string))
+	if payloadErr != nil {
+		return diag.FromErr(payloadErr)
+	}
+
+	body := payload.WriteDashboard
+	body.FolderId = conv.P(d.Get("folder_id").(string))
+
+	if _, updateErr := api.UpdateDashboard(d.Id(), body, nil); updateErr != nil {
+		return apiDiags(updateErr, "failed to update dashboard", "folder_id")
+	}
+
+	if d.HasChange("json") {
+		config, configErr := parseContentJSON(d.Get("json").(string))
+		if configErr != nil {
+			return diag.FromErr(configErr)
+		}
+
+		// the filters of the dashboard are replaced if they are not equivalent to the config, and its elements are updated in place
+		dashboard, dashboardErr := api.Dashboard(d.Id(), "dashboard_elements,dashboard_filters", nil)
+		if dashboardErr != nil {
+			return apiDiags(dashboardErr, "failed to read dashboard")
+		}
+		serialized, serializeErr := serializeContent(dashboard)
+		if serializeErr != nil {
+			return diag.FromErr(serializeErr)
+		}
+		remote, remoteErr := parseContentJSON(serialized)
+		if remoteErr != nil {
+			return diag.FromErr(remoteErr)
+		}
+
+		if !equivalentContentField(remote, config, "dashboard_filters") {
+			for _, f := range conv.Deref(dashboard.DashboardFilters) {
+				if _, delErr := api.DeleteDashboardFilter(conv.Deref(f.Id), nil); delErr != nil && !errors.Is(delErr, sdk.ErrNotFound) {
+					return apiDiags(delErr, "failed to delete dashboard filter")
+				}
+			}
+			if diags := createDashboardFilters(api, d.Id(), payload.DashboardFilters); diags.HasError() {
+				return diags
+			}
+		}
+
+		if !equivalentContentField(remote, config, "dashboard_elements") {
+			configElements, _ := config.(map[string]interface{})["dashboard_elements"].([]interface{})
+			diags := updateDashboardElements(api, d.Id(), conv.Deref(dashboard.DashboardElements), configElements, payload.DashboardElements)
+			if diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	return resourceDashboardRead(ctx, d, c)
+}
+
+func resourceDashboardDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	_, delErr := api.DeleteDashboard(d.Id(), nil)
+	if !errors.Is(delErr, sdk.ErrNotFound) {
+		return apiDiags(delErr, "failed to delete dashboard")
+	}
+
+	return nil
+}
+
+// expandDashboardPayload returns the writable fields, elements and filters of the JSON definition of a dashboard. The volatile fields of
+// the definition are ignored, so that a dashboard exported from another instance can be used as it is.
+func expandDashboardPayload(s string) (dashboardPayload, error) {
+	v, parseErr := parseContentJSON(s)
+	if parseErr != nil {
+		return dashboardPayload{}, parseErr
+	}
+
+	b, marshalErr := json.Marshal(v)
+	if marshalErr != nil {
+		return dashboardPayload{}, marshalErr
+	}
+
+	var payload dashboardPayload
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return dashboardPayload{}, fmt.Errorf("invalid dashboard definition: %w", err)
+	}
+
+	return payload, nil
+}
+
+func createDashboardFilters(api *sdk.LookerSDK, dashboardID string, filters []sdk.WriteCreateDashboardFilter) diag.Diagnostics {
+	for _, f := range filters {
+		f.DashboardId = dashboardID
+		if _, createErr := api.CreateDashboardFilter(f, "", nil); createErr != nil {
+			return apiDiags(createErr, fmt.Sprintf("failed to create filter %s of dashboard %s", f.Name, dashboardID))
+		}
+	}
+
+	return nil
+}
+
+func createDashboardElements(api *sdk.LookerSDK, dashboardID string, elements []sdk.WriteDashboardElement) diag.Diagnostics {
+	for i, e := range elements {
+		e.DashboardId = conv.P(dashboardID)
+		if _, createErr := api.CreateDashboardElement(sdk.RequestCreateDashboardElement{Body: e}, nil); createErr != nil {
+			return apiDiags(createErr, fmt.Sprintf("failed to create element %d of dashboard %s", i, dashboardID))
+		}
+	}
+
+	return nil
+}
+
+// updateDashboardElements updates the elements of the dashboard to match the elements of the config. The elements which match an
+// element of the config are updated in place when they are not equivalent to it, so that they keep their ids, which alerts and
+// scheduled plans refer to. The remaining elements of the dashboard are deleted, and the remaining elements of the config are created.
+func updateDashboardElements(api *sdk.LookerSDK, dashboardID string, remote []sdk.DashboardElement, config []interface{}, elements []sdk.WriteDashboardElement) diag.Diagnostics {
+	matches := matchDashboardElements(remote, elements)
+
+	matched := make([]bool, len(remote))
+	for _, j := range matches {
+		if j >= 0 {
+			matched[j] = true
+		}
+	}
+	for j, e := range remote {
+		if matched[j] {
+			continue
+		}
+		if _, delErr := api.DeleteDashboardElement(conv.Deref(e.Id), nil); delErr != nil && !errors.Is(delErr, sdk.ErrNotFound) {
+			return apiDiags(delErr, fmt.Sprintf("failed to delete element %s of dashboard %s", conv.Deref(e.Id), dashboardID))
+		}
+	}
+
+	for i, e := range elements {
+		j := matches[i]
+		if j < 0 {
+			e.DashboardId = conv.P(dashboardID)
+			if _, createErr := api.CreateDashboardElement(sdk.RequestCreateDashboardElement{Body: e}, nil); createErr != nil {
+				return apiDiags(createErr, fmt.Sprintf("failed to create element %d of dashboard %s", i, dashboardID))
+			}
+			continue
+		}
+
+		if i < len(config) {
+			serialized, serializeErr := serializeContent(remote[j])
+			if serializeErr != nil {
+				return diag.FromErr(serializeErr)
+			}
+			current, parseErr := parseContentJSON(serialized)
+			if parseErr != nil {
+				return diag.FromErr(parseErr)
+			}
+			if equivalentContent(current, config[i]) {
+				continue
+			}
+		}
+
+		id := conv.Deref(remote[j].Id)
+		if _, updateErr := api.UpdateDashboardElement(id, e, "", nil); updateErr != nil {
+			return apiDiags(updateErr, fmt.Sprintf("failed to update element %s of dashboard %s", id, dashboardID))
+		}
+	}
+
+	return nil
+}
+
+// matchDashboardElements returns the index of the element of the dashboard which matches each element of the config, or -1 if there
+// is none. Elements are matched by their title first, and the elements which are left are matched by their position.
+func matchDashboardElements(remote []sdk.DashboardElement, elements []sdk.WriteDashboardElement) []int {
+	matches := make([]int, len(elements))
+	matched := make([]bool, len(remote))
+
+	for i, e := range elements {
+		matches[i] = -1
+
+		title := dashboardElementTitle(e.Title, e.TitleText)
+		if title == "" {
+			continue
+		}
+		for j, r := range remote {
+			if !matched[j] && dashboardElementTitle(r.Title, r.TitleText) == title {
+				matches[i] = j
+				matched[j] = true
+				break
+			}
+		}
+	}
+
+	for i := range elements {
+		if matches[i] < 0 && i < len(remote) && !matched[i] {
+			matches[i] = i
+			matched[i] = true
+		}
+	}
+
+	return matches
+}
+
+// dashboardElementTitle returns the title of a dashboard element. Text tiles have a title_text instead of a title.
+func dashboardElementTitle(title, titleText *string) string {
+	if t := conv.Deref(title); t != "" {
+		return t
+	}
+	return conv.Deref(titleText)
+}
+
+// equivalentContentField returns true if the field of the remote content is equivalent to the field in config, or if the field is
+// not set in config.
+func equivalentContentField(remote, config interface{}, field string) bool {
+	r, _ := remote.(map[string]interface{})
+	c, _ := config.(map[string]interface{})
+
+	value, ok := c[field]
+	if !ok {
+		return true
+	}
+	return equivalentContent(r[field], value)
+}
+
+// validateContentLookml is a SchemaValidateFunc which checks that the LookML of content can be parsed.
+func validateContentLookml(i interface{}, k string) ([]string, []error) {
+	s, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := parseContentLookml(s); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+
+	return nil, nil
+}
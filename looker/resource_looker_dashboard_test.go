@@ -0,0 +1,237 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func TestAccLookerDashboard(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_dashboard")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardConfig("test-acc-dashboard", "Orders by status"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_dashboard.test_acc", "id", "31"),
+					resource.TestCheckResourceAttr("looker_dashboard.test_acc", "title", "test-acc-dashboard"),
+					resource.TestCheckResourceAttr("looker_dashboard.test_acc", "folder_id", "1"),
+					resource.TestCheckResourceAttr("looker_dashboard.test_acc", "content_metadata_id", "412"),
+				),
+			},
+			{
+				// the element is updated in place as its body text has changed, so it keeps its id, and the filter is kept
+				Config: testAccDashboardConfig("test-acc-dashboard-renamed", "Orders by status and month"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_dashboard.test_acc", "id", "31"),
+					resource.TestCheckResourceAttr("looker_dashboard.test_acc", "title", "test-acc-dashboard-renamed"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDashboardConfig(title, bodyText string) string {
+	return fmt.Sprintf(`
+	resource "looker_dashboard" "test_acc" {
+		folder_id = "1"
+		json = jsonencode({
+			title       = %q
+			description = "Orders"
+			dashboard_filters = [
+				{
+					name      = "Status"
+					title     = "Status"
+					type      = "field_filter"
+					model     = "thelook"
+					explore   = "orders"
+					dimension = "orders.status"
+				}
+			]
+			dashboard_elements = [
+				{
+					type       = "text"
+					title_text = "Welcome"
+					body_text  = %q
+				}
+			]
+		})
+	}
+	`, title, bodyText)
+}
+
+func testAccCheckDashboardDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "looker_dashboard" {
+			continue
+		}
+
+		// a deleted dashboard is moved to the trash
+		dashboard, err := client.Dashboard(r.Primary.ID, "", nil)
+		if errors.Is(err, sdk.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !conv.Deref(dashboard.Deleted) {
+			return fmt.Errorf("dashboard %s has not been deleted", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestExpandDashboardPayload(t *testing.T) {
+	payload, err := expandDashboardPayload(`{
+		"id": "31",
+		"title": "Orders",
+		"folder_id": "7",
+		"dashboard_filters": [{"id": "88", "name": "Status", "title": "Status", "type": "field_filter"}],
+		"dashboard_elements": [{"id": "45", "query_id": "1203", "type": "vis", "query": {"id": "1203", "model": "thelook", "view": "orders", "fields": ["orders.count"]}}]
+	}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if payload.Title == nil || *payload.Title != "Orders" {
+		t.Errorf("expected title Orders, got %v", payload.Title)
+	}
+	if payload.FolderId != nil {
+		t.Errorf("expected the folder of the definition to be ignored, got %s", *payload.FolderId)
+	}
+	if len(payload.DashboardFilters) != 1 || payload.DashboardFilters[0].Name != "Status" {
+		t.Errorf("expected the Status filter, got %+v", payload.DashboardFilters)
+	}
+	if len(payload.DashboardElements) != 1 {
+		t.Fatalf("expected 1 element, got %d", len(payload.DashboardElements))
+	}
+	if e := payload.DashboardElements[0]; e.QueryId != nil || e.Query == nil || e.Query.Model != "thelook" {
+		t.Errorf("expected the element to create its query instead of using the query of another instance, got %+v", e)
+	}
+}
+
+func TestMatchDashboardElements(t *testing.T) {
+	remote := []sdk.DashboardElement{
+		{Id: conv.P("45"), Type: conv.P("text"), TitleText: conv.P("Welcome")},
+		{Id: conv.P("46"), Type: conv.P("vis"), Title: conv.P("Orders")},
+		{Id: conv.P("47"), Type: conv.P("vis"), Title: conv.P("Revenue")},
+	}
+
+	tests := []struct {
+		name     string
+		elements []sdk.WriteDashboardElement
+		want     []int
+	}{
+		{
+			name: "elements are matched by title when they are reordered",
+			elements: []sdk.WriteDashboardElement{
+				{Type: conv.P("vis"), Title: conv.P("Revenue")},
+				{Type: conv.P("text"), TitleText: conv.P("Welcome")},
+				{Type: conv.P("vis"), Title: conv.P("Orders")},
+			},
+			want: []int{2, 0, 1},
+		},
+		{
+			name: "renamed elements are matched by position",
+			elements: []sdk.WriteDashboardElement{
+				{Type: conv.P("text"), TitleText: conv.P("Welcome")},
+				{Type: conv.P("vis"), Title: conv.P("Orders by status")},
+			},
+			want: []int{0, 1},
+		},
+		{
+			name: "new elements are not matched",
+			elements: []sdk.WriteDashboardElement{
+				{Type: conv.P("text"), TitleText: conv.P("Welcome")},
+				{Type: conv.P("vis"), Title: conv.P("Orders")},
+				{Type: conv.P("vis"), Title: conv.P("Revenue")},
+				{Type: conv.P("vis"), Title: conv.P("Customers")},
+			},
+			want: []int{0, 1, 2, -1},
+		},
+		{
+			name: "an element is only matched once",
+			elements: []sdk.WriteDashboardElement{
+				{Type: conv.P("vis"), Title: conv.P("Orders")},
+				{Type: conv.P("vis"), Title: conv.P("Orders")},
+			},
+			want: []int{1, -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchDashboardElements(remote, tt.elements); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected matches %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdateDashboardElementsKeepsIDs(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	c := newFakeLookerServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "{}")
+	}))
+
+	remote := []sdk.DashboardElement{
+		{Id: conv.P("47"), Type: conv.P("text"), TitleText: conv.P("Removed"), BodyText: conv.P("Removed")},
+		{Id: conv.P("45"), Type: conv.P("text"), TitleText: conv.P("Welcome"), BodyText: conv.P("Orders by status")},
+		{Id: conv.P("46"), Type: conv.P("text"), TitleText: conv.P("Notes"), BodyText: conv.P("Unchanged")},
+	}
+	definition := `{"dashboard_elements": [
+		{"type": "text", "title_text": "Welcome", "body_text": "Orders by status and month"},
+		{"type": "text", "title_text": "Notes", "body_text": "Unchanged"},
+		{"type": "text", "title_text": "Added", "body_text": "Added"}
+	]}`
+
+	payload, err := expandDashboardPayload(definition)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, err := parseContentJSON(definition)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	configElements := config.(map[string]interface{})["dashboard_elements"].([]interface{})
+	if diags := updateDashboardElements(c.LookerSDK, "31", remote, configElements, payload.DashboardElements); diags.HasError() {
+		t.Fatalf("failed to update the elements: %v", diags)
+	}
+
+	// the changed element is updated in place, the unchanged element is left alone, and only the removed element is deleted
+	want := []string{
+		"DELETE /api/4.0/dashboard_elements/47",
+		"PATCH /api/4.0/dashboard_elements/45",
+		"POST /api/4.0/dashboard_elements",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected requests %v, got %v", want, calls)
+	}
+}
@@ -0,0 +1,137 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+// datagroupTriggerNow returns the time the datagroups are triggered at. It is replaced in tests, so that the requests to update
+// datagroups can be replayed.
+var datagroupTriggerNow = time.Now
+
+func resourceDatagroupTrigger() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource triggers a datagroup of a Looker instance when it is created and whenever its `triggers` change, eg. to rebuild the persistent derived tables of the datagroup when an upstream ETL job has finished. " +
+			"Destroying this resource does not change the datagroup.",
+
+		CreateContext: resourceDatagroupTriggerCreate,
+		ReadContext:   resourceDatagroupTriggerRead,
+		UpdateContext: resourceDatagroupTriggerUpdate,
+		DeleteContext: resourceDatagroupTriggerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"datagroup_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the datagroup",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values which trigger the datagroup when they change, eg. the id of the run of an ETL job",
+			},
+			"reset": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "triggered_at",
+				ValidateFunc: validation.StringInSlice([]string{"triggered_at", "stale_before"}, false),
+				Description:  "The field of the datagroup which is set to the current time. `triggered_at` marks the datagroup as triggered, which rebuilds its persistent derived tables and runs the schedules which use it, and `stale_before` only invalidates its cache",
+			},
+			"reset_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time at which the datagroup was last reset by this resource, in RFC 3339 format",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the datagroup",
+			},
+			"model_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the LookML model of the datagroup",
+			},
+		},
+	}
+}
+
+func resourceDatagroupTriggerCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	d.SetId(d.Get("datagroup_id").(string))
+
+	if diags := resetDatagroup(c.(*lookerClient).LookerSDK, d); diags.HasError() {
+		d.SetId("")
+		return diags
+	}
+
+	return resourceDatagroupTriggerRead(ctx, d, c)
+}
+
+func resourceDatagroupTriggerRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	datagroup, datagroupErr := api.Datagroup(d.Id(), nil)
+	if errors.Is(datagroupErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if datagroupErr != nil {
+		return apiDiags(datagroupErr, "failed to read datagroup")
+	}
+
+	result := multierror.Append(
+		d.Set("datagroup_id", datagroup.Id),
+		d.Set("name", datagroup.Name),
+		d.Set("model_name", datagroup.ModelName),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceDatagroupTriggerUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	if d.HasChanges("triggers", "reset") {
+		if diags := resetDatagroup(c.(*lookerClient).LookerSDK, d); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceDatagroupTriggerRead(ctx, d, c)
+}
+
+func resourceDatagroupTriggerDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	// a datagroup is defined in LookML, so there is nothing to delete
+	return nil
+}
+
+// resetDatagroup sets the reset field of the datagroup to the current time.
+func resetDatagroup(api *sdk.LookerSDK, d *schema.ResourceData) diag.Diagnostics {
+	now := datagroupTriggerNow()
+
+	if _, updateErr := api.UpdateDatagroup(d.Id(), expandDatagroupReset(d.Get("reset").(string), now), nil); updateErr != nil {
+		return apiDiags(updateErr, "failed to reset datagroup "+d.Id())
+	}
+
+	return diag.FromErr(d.Set("reset_at", now.UTC().Format(time.RFC3339)))
+}
+
+// expandDatagroupReset returns the body of the request which sets the reset field of a datagroup to the time t.
+func expandDatagroupReset(reset string, t time.Time) sdk.WriteDatagroup {
+	if reset == "stale_before" {
+		return sdk.WriteDatagroup{StaleBefore: conv.P(t.Unix())}
+	}
+	return sdk.WriteDatagroup{TriggeredAt: conv.P(t.Unix())}
+}
@@ -0,0 +1,69 @@
+package looker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerDatagroupTrigger(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_datagroup_trigger")
+	defer stop() //nolint:errcheck
+
+	// the time is fixed, as it is in the body of the recorded requests
+	datagroupTriggerNow = func() time.Time { return time.Date(2026, 10, 14, 9, 12, 4, 0, time.UTC) }
+	defer func() { datagroupTriggerNow = time.Now }()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "looker_datagroup_trigger" "test_acc" {
+					datagroup_id = "3"
+					triggers = {
+						etl_run_id = "1041"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_datagroup_trigger.test_acc", "id", "3"),
+					resource.TestCheckResourceAttr("looker_datagroup_trigger.test_acc", "name", "orders_datagroup"),
+					resource.TestCheckResourceAttr("looker_datagroup_trigger.test_acc", "model_name", "thelook"),
+					resource.TestCheckResourceAttr("looker_datagroup_trigger.test_acc", "reset_at", "2026-10-14T09:12:04Z"),
+				),
+			},
+			{
+				// the cache of the datagroup is invalidated as the triggers have changed
+				Config: `
+				resource "looker_datagroup_trigger" "test_acc" {
+					datagroup_id = "3"
+					reset        = "stale_before"
+					triggers = {
+						etl_run_id = "1042"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_datagroup_trigger.test_acc", "id", "3"),
+					resource.TestCheckResourceAttr("looker_datagroup_trigger.test_acc", "reset", "stale_before"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandDatagroupReset(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 12, 4, 0, time.UTC)
+
+	triggered := expandDatagroupReset("triggered_at", now)
+	if triggered.TriggeredAt == nil || *triggered.TriggeredAt != now.Unix() || triggered.StaleBefore != nil {
+		t.Errorf("expected only triggered_at to be set, got %+v", triggered)
+	}
+
+	stale := expandDatagroupReset("stale_before", now)
+	if stale.StaleBefore == nil || *stale.StaleBefore != now.Unix() || stale.TriggeredAt != nil {
+		t.Errorf("expected only stale_before to be set, got %+v", stale)
+	}
+}
@@ -0,0 +1,169 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func resourceFolder() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates a folder in a Looker instance. Folders can be nested by setting `parent_id` to the id of another folder. Folders in the personal folder of a user should be created with `run_as_user_id` set to the id of the user.",
+
+		CreateContext: resourceFolderCreate,
+		ReadContext:   resourceFolderRead,
+		UpdateContext: resourceFolderUpdate,
+		DeleteContext: resourceFolderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the folder. Folder names must be unique within the parent folder",
+			},
+			"parent_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the parent folder. Use the id of the Shared folder to create a top level folder",
+			},
+			"run_as_user_id": runAsSchema(),
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the folder",
+			},
+			"content_metadata_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the content metadata of the folder, used to manage access to the folder",
+			},
+			"creator_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the user who created the folder",
+			},
+		},
+	}
+}
+
+func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	name := d.Get("name").(string)
+	folder, folderErr := api.CreateFolder(
+		sdk.CreateFolder{
+			Name:     name,
+			ParentId: d.Get("parent_id").(string),
+		}, nil,
+	)
+	if folderErr != nil {
+		return apiDiags(folderErr, "failed to create folder", "name", "parent_id")
+	}
+
+	if folder.Id == nil {
+		return diag.Errorf("folder %s has missing id", name)
+	}
+	d.SetId(*folder.Id)
+
+	return resourceFolderRead(ctx, d, c)
+}
+
+func resourceFolderRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	folder, folderErr := api.Folder(d.Id(), "id,name,parent_id,content_metadata_id,creator_id", nil)
+	if errors.Is(folderErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if folderErr != nil {
+		return diag.FromErr(folderErr)
+	}
+
+	result := multierror.Append(
+		d.Set("name", folder.Name),
+		d.Set("parent_id", folder.ParentId),
+		d.Set("id", folder.Id),
+		d.Set("content_metadata_id", folder.ContentMetadataId),
+		d.Set("creator_id", folder.CreatorId),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	if !d.HasChanges("name", "parent_id") {
+		return nil
+	}
+
+	_, folderErr := api.UpdateFolder(d.Id(),
+		sdk.UpdateFolder{
+			Name:     conv.PString(d.Get("name").(string)),
+			ParentId: conv.PString(d.Get("parent_id").(string)),
+		}, nil,
+	)
+	if folderErr != nil {
+		return apiDiags(folderErr, "failed to update folder", "name", "parent_id")
+	}
+
+	return resourceFolderRead(ctx, d, c)
+}
+
+func resourceFolderDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	// deleting a folder in looker also deletes every look, dashboard and sub folder within it, so only empty folders are deleted
+	folder, folderErr := api.Folder(d.Id(), "id,name,child_count,looks,dashboards", nil)
+	if errors.Is(folderErr, sdk.ErrNotFound) {
+		return nil
+	}
+	if folderErr != nil {
+		return diag.FromErr(folderErr)
+	}
+
+	if content := folderContent(folder); content != "" {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "folder is not empty",
+				Detail:   fmt.Sprintf("folder %s (%s) cannot be deleted because it still contains %s. Move or delete the content of the folder before destroying it.", folder.Name, d.Id(), content),
+			},
+		}
+	}
+
+	_, delErr := api.DeleteFolder(d.Id(), nil)
+	if !errors.Is(delErr, sdk.ErrNotFound) {
+		return diag.FromErr(delErr)
+	}
+
+	return nil
+}
+
+// folderContent returns a human readable summary of the content within a folder, or an empty string if the folder is empty.
+func folderContent(folder sdk.Folder) string {
+	var content []string
+	if folder.ChildCount != nil && *folder.ChildCount > 0 {
+		content = append(content, fmt.Sprintf("%d sub folder(s)", *folder.ChildCount))
+	}
+	if folder.Looks != nil && len(*folder.Looks) > 0 {
+		content = append(content, fmt.Sprintf("%d look(s)", len(*folder.Looks)))
+	}
+	if folder.Dashboards != nil && len(*folder.Dashboards) > 0 {
+		content = append(content, fmt.Sprintf("%d dashboard(s)", len(*folder.Dashboards)))
+	}
+
+	return strings.Join(content, ", ")
+}
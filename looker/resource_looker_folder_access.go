@@ -0,0 +1,407 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func resourceFolderAccess() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource manages which groups and users can view or edit a Looker folder. There can only be one `looker_folder_access` resource per folder. By default this is an additive resource that grants access in addition to the access already configured in Looker. If `authoritative` is set, any access not defined in this resource is removed from the folder.",
+
+		CreateContext: resourceFolderAccessCreate,
+		ReadContext:   resourceFolderAccessRead,
+		UpdateContext: resourceFolderAccessUpdate,
+		DeleteContext: resourceFolderAccessDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFolderAccessImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"folder_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the folder",
+				ForceNew:    true,
+			},
+			"inherits": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the folder inherits its access from its parent folder. Access can only be granted when this is set to false",
+			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, any access on the folder that is not defined in this resource is removed. If false, access is granted in addition to the access already configured in Looker",
+			},
+			"access": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "An unordered list of groups and users with access to the folder",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The id of the group to grant access to. Exactly one of `group_id` and `user_id` must be set",
+						},
+						"user_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The id of the user to grant access to. Exactly one of `group_id` and `user_id` must be set",
+						},
+						"permission_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of access to grant, either `view` or `edit`",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+								string(sdk.PermissionType_View), string(sdk.PermissionType_Edit),
+							}, false)),
+						},
+					},
+				},
+			},
+			"content_metadata_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the content metadata of the folder",
+			},
+		},
+	}
+}
+
+func resourceFolderAccessCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// the access of the folder is read before it is changed, so changes to the access of the same folder are serialised
+	lockKey := folderAccessLockKey(d.Get("folder_id").(string))
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
+
+	folderID := d.Get("folder_id").(string)
+	contentMetadataID, cmErr := getFolderContentMetadataID(api, folderID)
+	if cmErr != nil {
+		return diag.FromErr(cmErr)
+	}
+
+	desired, accessErr := expandFolderAccess(d.Get("access").(*schema.Set))
+	if accessErr != nil {
+		return diag.FromErr(accessErr)
+	}
+
+	inherits := d.Get("inherits").(bool)
+	if inherits && len(desired) > 0 {
+		return diag.Errorf("access cannot be granted on folder %s while it inherits access from its parent, set inherits to false", folderID)
+	}
+
+	_, updateErr := api.UpdateContentMetadata(contentMetadataID, sdk.WriteContentMeta{Inherits: conv.PBool(inherits)}, nil)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	syncErr := syncFolderAccess(api, contentMetadataID, nil, desired, d.Get("authoritative").(bool))
+	if syncErr != nil {
+		return diag.FromErr(syncErr)
+	}
+
+	d.SetId(folderID)
+
+	return resourceFolderAccessRead(ctx, d, c)
+}
+
+// resourceFolderAccessRead reads the access set on the folder in looker. If the resource is authoritative all access is set to the state,
+// otherwise only access granted to groups and users already in the terraform state is set.
+func resourceFolderAccessRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	contentMetadataID, cmErr := getFolderContentMetadataID(api, d.Id())
+	if errors.Is(cmErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if cmErr != nil {
+		return diag.FromErr(cmErr)
+	}
+
+	cm, cmReadErr := api.ContentMetadata(contentMetadataID, "id,inherits", nil)
+	if cmReadErr != nil {
+		return diag.FromErr(cmReadErr)
+	}
+
+	accesses, accessErr := api.AllContentMetadataAccesses(contentMetadataID, "", nil)
+	if accessErr != nil {
+		return diag.FromErr(accessErr)
+	}
+
+	managed, expandErr := expandFolderAccess(d.Get("access").(*schema.Set))
+	if expandErr != nil {
+		return diag.FromErr(expandErr)
+	}
+
+	authoritative := d.Get("authoritative").(bool)
+	access := make([]interface{}, 0, len(accesses))
+	for _, a := range accesses {
+		if _, ok := managed[contentMetadataAccessKey(a)]; !authoritative && !ok {
+			continue
+		}
+		access = append(access, flattenContentMetadataAccess(a))
+	}
+
+	result := multierror.Append(
+		d.Set("folder_id", d.Id()),
+		d.Set("content_metadata_id", contentMetadataID),
+		d.Set("inherits", cm.Inherits),
+		d.Set("access", access),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceFolderAccessUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// the access of the folder is read before it is changed, so changes to the access of the same folder are serialised
+	lockKey := folderAccessLockKey(d.Id())
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
+
+	o, n := d.GetChange("access")
+	managed, oErr := expandFolderAccess(o.(*schema.Set))
+	if oErr != nil {
+		return diag.FromErr(oErr)
+	}
+	desired, nErr := expandFolderAccess(n.(*schema.Set))
+	if nErr != nil {
+		return diag.FromErr(nErr)
+	}
+
+	inherits := d.Get("inherits").(bool)
+	if inherits && len(desired) > 0 {
+		return diag.Errorf("access cannot be granted on folder %s while it inherits access from its parent, set inherits to false", d.Id())
+	}
+
+	contentMetadataID := d.Get("content_metadata_id").(string)
+
+	// access can only be changed once the folder no longer inherits from its parent
+	if d.HasChange("inherits") && !inherits {
+		_, updateErr := api.UpdateContentMetadata(contentMetadataID, sdk.WriteContentMeta{Inherits: conv.PBool(false)}, nil)
+		if updateErr != nil {
+			return diag.FromErr(updateErr)
+		}
+	}
+
+	syncErr := syncFolderAccess(api, contentMetadataID, managed, desired, d.Get("authoritative").(bool))
+	if syncErr != nil {
+		return diag.FromErr(syncErr)
+	}
+
+	if d.HasChange("inherits") && inherits {
+		_, updateErr := api.UpdateContentMetadata(contentMetadataID, sdk.WriteContentMeta{Inherits: conv.PBool(true)}, nil)
+		if updateErr != nil {
+			return diag.FromErr(updateErr)
+		}
+	}
+
+	return resourceFolderAccessRead(ctx, d, c)
+}
+
+// resourceFolderAccessDelete removes the access managed by this resource. An authoritative resource owns all access on the folder,
+// so the folder is reverted to inherit access from its parent.
+func resourceFolderAccessDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// the access of the folder is read before it is changed, so changes to the access of the same folder are serialised
+	lockKey := folderAccessLockKey(d.Id())
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
+
+	managed, expandErr := expandFolderAccess(d.Get("access").(*schema.Set))
+	if expandErr != nil {
+		return diag.FromErr(expandErr)
+	}
+
+	contentMetadataID := d.Get("content_metadata_id").(string)
+	authoritative := d.Get("authoritative").(bool)
+
+	syncErr := syncFolderAccess(api, contentMetadataID, managed, nil, authoritative)
+	if errors.Is(syncErr, sdk.ErrNotFound) {
+		return nil
+	}
+	if syncErr != nil {
+		return diag.FromErr(syncErr)
+	}
+
+	if authoritative {
+		_, updateErr := api.UpdateContentMetadata(contentMetadataID, sdk.WriteContentMeta{Inherits: conv.PBool(true)}, nil)
+		if !errors.Is(updateErr, sdk.ErrNotFound) {
+			return diag.FromErr(updateErr)
+		}
+	}
+
+	return nil
+}
+
+// resourceFolderAccessImport imports all access currently set on the folder.
+func resourceFolderAccessImport(ctx context.Context, d *schema.ResourceData, c interface{}) ([]*schema.ResourceData, error) {
+	api := c.(*lookerClient).LookerSDK
+
+	contentMetadataID, cmErr := getFolderContentMetadataID(api, d.Id())
+	if cmErr != nil {
+		return nil, cmErr
+	}
+
+	accesses, accessErr := api.AllContentMetadataAccesses(contentMetadataID, "", nil)
+	if accessErr != nil {
+		return nil, accessErr
+	}
+
+	access := make([]interface{}, len(accesses))
+	for i, a := range accesses {
+		access[i] = flattenContentMetadataAccess(a)
+	}
+
+	resErr := multierror.Append(
+		d.Set("folder_id", d.Id()),
+		d.Set("authoritative", false),
+		d.Set("access", access),
+	).ErrorOrNil()
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// syncFolderAccess reconciles the access set on a content metadata object with the desired access. Access in looker that is not desired
+// is removed if the access was previously managed by terraform, or if authoritative is true.
+func syncFolderAccess(api *sdk.LookerSDK, contentMetadataID string, managed, desired map[string]sdk.ContentMetaGroupUser, authoritative bool) error {
+	current, accessErr := api.AllContentMetadataAccesses(contentMetadataID, "", nil)
+	if accessErr != nil {
+		return accessErr
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, a := range current {
+		if a.Id == nil {
+			return errors.New("the folder has access with a missing id")
+		}
+
+		key := contentMetadataAccessKey(a)
+		existing[key] = true
+
+		want, ok := desired[key]
+		if !ok {
+			if _, isManaged := managed[key]; authoritative || isManaged {
+				if _, delErr := api.DeleteContentMetadataAccess(*a.Id, nil); delErr != nil && !errors.Is(delErr, sdk.ErrNotFound) {
+					return fmt.Errorf("failed to remove access for %s: %w", key, delErr)
+				}
+			}
+			continue
+		}
+
+		if a.PermissionType != nil && *a.PermissionType == *want.PermissionType {
+			continue
+		}
+
+		want.ContentMetadataId = conv.P(contentMetadataID)
+		if _, updateErr := api.UpdateContentMetadataAccess(*a.Id, want, nil); updateErr != nil {
+			return fmt.Errorf("failed to update access for %s: %w", key, updateErr)
+		}
+	}
+
+	for key, want := range desired {
+		if existing[key] {
+			continue
+		}
+
+		want.ContentMetadataId = conv.P(contentMetadataID)
+		if _, createErr := api.CreateContentMetadataAccess(want, false, nil); createErr != nil {
+			return fmt.Errorf("failed to grant access for %s: %w", key, createErr)
+		}
+	}
+
+	return nil
+}
+
+// expandFolderAccess converts the access set into a map of content metadata access keyed by the group or user being granted access.
+func expandFolderAccess(set *schema.Set) (map[string]sdk.ContentMetaGroupUser, error) {
+	access := make(map[string]sdk.ContentMetaGroupUser, set.Len())
+	for _, v := range set.List() {
+		a := v.(map[string]interface{})
+
+		groupID, userID := a["group_id"].(string), a["user_id"].(string)
+		if (groupID == "") == (userID == "") {
+			return nil, errors.New("exactly one of group_id and user_id must be set for each access")
+		}
+
+		write := sdk.ContentMetaGroupUser{
+			GroupId:        conv.PString(groupID),
+			UserId:         conv.PString(userID),
+			PermissionType: conv.P(sdk.PermissionType(a["permission_type"].(string))),
+		}
+
+		key := contentMetadataAccessKey(write)
+		if _, ok := access[key]; ok {
+			return nil, fmt.Errorf("access for %s is defined more than once", key)
+		}
+		access[key] = write
+	}
+
+	return access, nil
+}
+
+func flattenContentMetadataAccess(a sdk.ContentMetaGroupUser) map[string]interface{} {
+	access := map[string]interface{}{
+		"group_id":        a.GroupId,
+		"user_id":         a.UserId,
+		"permission_type": "",
+	}
+	if a.PermissionType != nil {
+		access["permission_type"] = string(*a.PermissionType)
+	}
+
+	return access
+}
+
+// contentMetadataAccessKey returns a key identifying the group or user that is granted access, eg. group:<group_id> or user:<user_id>.
+func contentMetadataAccessKey(a sdk.ContentMetaGroupUser) string {
+	if a.GroupId != nil && *a.GroupId != "" {
+		return fmt.Sprintf("group:%s", *a.GroupId)
+	}
+	if a.UserId != nil {
+		return fmt.Sprintf("user:%s", *a.UserId)
+	}
+	return ""
+}
+
+// folderAccessLockKey returns the key of the lock that guards the access of the folder.
+func folderAccessLockKey(folderID string) string {
+	return "folder_access/" + folderID
+}
+
+func getFolderContentMetadataID(api *sdk.LookerSDK, folderID string) (string, error) {
+	folder, folderErr := api.Folder(folderID, "id,content_metadata_id", nil)
+	if folderErr != nil {
+		return "", folderErr
+	}
+
+	if folder.ContentMetadataId == nil {
+		return "", fmt.Errorf("folder with id %s has missing content_metadata_id", folderID)
+	}
+
+	return *folder.ContentMetadataId, nil
+}
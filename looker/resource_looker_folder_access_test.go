@@ -0,0 +1,114 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLookerFolderAccess(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_folder_access")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "looker_folder" "test_acc" {
+					name      = "test-acc-folder-access"
+					parent_id = "1"
+				}
+
+				resource "looker_group" "test_acc" {
+					name = "test-acc-folder-access-group"
+				}
+
+				resource "looker_folder_access" "test_acc" {
+					folder_id     = looker_folder.test_acc.id
+					authoritative = true
+
+					access {
+						group_id        = looker_group.test_acc.id
+						permission_type = "view"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_folder_access.test_acc", "inherits", "false"),
+					resource.TestCheckResourceAttr("looker_folder_access.test_acc", "access.#", "1"),
+					testAccFolderAccess("looker_folder_access.test_acc", "looker_group.test_acc", "view"),
+				),
+			},
+			{
+				Config: `
+				resource "looker_folder" "test_acc" {
+					name      = "test-acc-folder-access"
+					parent_id = "1"
+				}
+
+				resource "looker_group" "test_acc" {
+					name = "test-acc-folder-access-group"
+				}
+
+				resource "looker_folder_access" "test_acc" {
+					folder_id     = looker_folder.test_acc.id
+					authoritative = true
+
+					access {
+						group_id        = looker_group.test_acc.id
+						permission_type = "edit"
+					}
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_folder_access.test_acc", "access.#", "1"),
+					testAccFolderAccess("looker_folder_access.test_acc", "looker_group.test_acc", "edit"),
+				),
+			},
+		},
+	})
+}
+
+// testAccFolderAccess checks that the group is the only group or user with access to the folder, and has the given permission type.
+func testAccFolderAccess(folderAccessResource, groupResource, permissionType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		folderAccessRes, ok := s.RootModule().Resources[folderAccessResource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", folderAccessResource)
+		}
+		if folderAccessRes.Primary.ID == "" {
+			return errors.New("folder access ID is not set")
+		}
+
+		groupRes, ok := s.RootModule().Resources[groupResource]
+		if !ok {
+			return fmt.Errorf("Not found: %s", groupResource)
+		}
+		if groupRes.Primary.ID == "" {
+			return errors.New("group ID is not set")
+		}
+
+		client := testAccProvider.Meta().(*lookerClient)
+
+		accesses, err := client.AllContentMetadataAccesses(folderAccessRes.Primary.Attributes["content_metadata_id"], "", nil)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve access for folder with id %v: %w", folderAccessRes.Primary.ID, err)
+		}
+
+		if len(accesses) != 1 {
+			return fmt.Errorf("expected exactly one access on the folder, got %d", len(accesses))
+		}
+		if accesses[0].GroupId == nil || *accesses[0].GroupId != groupRes.Primary.ID {
+			return fmt.Errorf("access is not granted to group %s", groupRes.Primary.ID)
+		}
+		if accesses[0].PermissionType == nil || string(*accesses[0].PermissionType) != permissionType {
+			return fmt.Errorf("expected permission type %s, got %v", permissionType, accesses[0].PermissionType)
+		}
+
+		return nil
+	}
+}
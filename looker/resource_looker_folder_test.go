@@ -0,0 +1,93 @@
+package looker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func init() {
+	// Add a sweeper to remove folders that have names starting with `test-acc`.
+	resource.AddTestSweepers(
+		"looker_folder",
+		&resource.Sweeper{
+			Name: "looker_folder",
+			F: func(_ string) error {
+				c, err := newTestLookerSDK()
+				if err != nil {
+					return err
+				}
+
+				folders, err := c.SearchFolders(sdk.RequestSearchFolders{
+					Name: conv.PString("test-acc%"),
+				}, nil)
+				if err != nil {
+					return err
+				}
+
+				for _, f := range folders {
+					// a folder may have already been deleted with its parent folder
+					if _, err := c.DeleteFolder(*f.Id, nil); err != nil && !errors.Is(err, sdk.ErrNotFound) {
+						return err
+					}
+				}
+
+				return nil
+			},
+		},
+	)
+}
+
+func TestAccLookerFolder(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_folder")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "looker_folder" "test_acc_parent" {
+					name      = "test-acc-folder-parent"
+					parent_id = "1"
+				}
+
+				resource "looker_folder" "test_acc" {
+					name      = "test-acc-folder"
+					parent_id = looker_folder.test_acc_parent.id
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_folder.test_acc", "name", "test-acc-folder"),
+					resource.TestCheckResourceAttrPair("looker_folder.test_acc", "parent_id", "looker_folder.test_acc_parent", "id"),
+					resource.TestCheckResourceAttrSet("looker_folder.test_acc", "content_metadata_id"),
+				),
+			},
+			{
+				Config: `
+				resource "looker_folder" "test_acc_parent" {
+					name      = "test-acc-folder-parent"
+					parent_id = "1"
+				}
+
+				resource "looker_folder" "test_acc" {
+					name      = "test-acc-folder-renamed"
+					parent_id = "1"
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_folder.test_acc", "name", "test-acc-folder-renamed"),
+					resource.TestCheckResourceAttr("looker_folder.test_acc", "parent_id", "1"),
+				),
+			},
+			{
+				ResourceName:      "looker_folder.test_acc",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
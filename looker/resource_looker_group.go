@@ -44,7 +44,7 @@ func resourceGroup() *schema.Resource {
 }
 
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	name := d.Get("name").(string)
 	group, grErr := api.CreateGroup(
@@ -54,7 +54,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, c interfac
 		"id,name", nil,
 	)
 	if grErr != nil {
-		return diag.FromErr(grErr)
+		return apiDiags(grErr, "failed to create group", "name")
 	}
 
 	if group.Id == nil {
@@ -66,7 +66,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, c interfac
 }
 
 func resourceGroupRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	group, grErr := api.Group(d.Id(), "id,name,externally_managed", nil)
 	if errors.Is(grErr, sdk.ErrNotFound) {
@@ -87,7 +87,7 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, c interface{
 }
 
 func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	if !d.HasChange("name") {
 		return nil
@@ -100,13 +100,13 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, c interfac
 		"", nil,
 	)
 	if grErr != nil {
-		return diag.FromErr(grErr)
+		return apiDiags(grErr, "failed to update group", "name")
 	}
 	return resourceGroupRead(ctx, d, c)
 }
 
 func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, delErr := api.DeleteGroup(d.Id(), nil)
 	if !errors.Is(delErr, sdk.ErrNotFound) {
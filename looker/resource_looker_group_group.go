@@ -49,7 +49,7 @@ func resourceGroupGroup() *schema.Resource {
 }
 
 func resourceGroupGroupCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	parentGroupID := d.Get("parent_group_id").(string)
 	groupID := d.Get("group_id").(string)
@@ -70,7 +70,7 @@ func resourceGroupGroupCreate(ctx context.Context, d *schema.ResourceData, c int
 }
 
 func resourceGroupGroupRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	groups, grErr := api.SearchGroupsWithHierarchy(sdk.RequestSearchGroupsWithHierarchy{
 		Id: conv.PString(d.Get("group_id").(string)),
@@ -94,7 +94,7 @@ func resourceGroupGroupRead(ctx context.Context, d *schema.ResourceData, c inter
 }
 
 func resourceGroupGroupDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	delErr := api.DeleteGroupFromGroup(
 		d.Get("parent_group_id").(string),
@@ -112,7 +112,7 @@ func resourceGroupGroupImport(ctx context.Context, d *schema.ResourceData, c int
 	// id is <parent_group_id>_<group_id>
 	s := strings.Split(d.Id(), "_")
 	if len(s) < 2 {
-		diag.Errorf("invalid id, should be of the form <parent_group_id>_<group_id>")
+		return nil, fmt.Errorf("invalid id %q, should be of the form <parent_group_id>_<group_id>", d.Id())
 	}
 
 	resErr := multierror.Append(
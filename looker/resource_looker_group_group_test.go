@@ -91,7 +91,7 @@ func testAccGroupGroupBinding(parentGroupResource, childGroupResource string) re
 			return errors.New("child group ID is not set")
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		parentSubGroups, err := client.AllGroupGroups(parentRes.Primary.ID, "", nil)
 		if err != nil {
@@ -2,6 +2,7 @@ package looker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -47,7 +48,7 @@ func resourceGroupUser() *schema.Resource {
 }
 
 func resourceGroupUserCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	groupID := d.Get("group_id").(string)
 	userID := d.Get("user_id").(string)
@@ -69,7 +70,7 @@ func resourceGroupUserCreate(ctx context.Context, d *schema.ResourceData, c inte
 }
 
 func resourceGroupUserRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userIDs, usersErr := api.SearchUsers(sdk.RequestSearchUsers{
 		GroupId: conv.PString(d.Get("group_id").(string)),
@@ -96,7 +97,7 @@ func resourceGroupUserRead(ctx context.Context, d *schema.ResourceData, c interf
 }
 
 func resourceGroupUserUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	oldUsr, newUsr := d.GetChange("user_id")
 	oldGr, newGr := d.GetChange("group_id")
@@ -122,16 +123,21 @@ func resourceGroupUserUpdate(ctx context.Context, d *schema.ResourceData, c inte
 }
 
 func resourceGroupUserDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
-	return diag.FromErr(api.DeleteGroupUser(d.Get("group_id").(string), d.Get("user_id").(string), nil))
+	delErr := api.DeleteGroupUser(d.Get("group_id").(string), d.Get("user_id").(string), nil)
+	if !errors.Is(delErr, sdk.ErrNotFound) {
+		return diag.FromErr(delErr)
+	}
+
+	return nil
 }
 
 func resourceGroupUserImport(ctx context.Context, d *schema.ResourceData, c interface{}) ([]*schema.ResourceData, error) {
 	// id is <user_id>_<group_id>
 	s := strings.Split(d.Id(), "_")
 	if len(s) < 2 {
-		diag.Errorf("invalid id, should be of the form <user_id>_<group_id>")
+		return nil, fmt.Errorf("invalid id %q, should be of the form <user_id>_<group_id>", d.Id())
 	}
 
 	resErr := multierror.Append(
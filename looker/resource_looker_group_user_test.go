@@ -110,7 +110,7 @@ func testAccGroupUserBinding(userResource, groupResource string) resource.TestCh
 			return errors.New("group ID is not set")
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		user, err := client.User(userRes.Primary.ID, "", nil)
 		if err != nil {
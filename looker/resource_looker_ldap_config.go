@@ -0,0 +1,539 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+// ldapConfigID is the id of the looker_ldap_config resource. There is only one LDAP config in a Looker instance.
+const ldapConfigID = "ldap"
+
+// ldapConfigAttrs are the attributes of looker_ldap_config which are sent in the body of the LDAP config, and can be reported by the
+// validation errors of the Looker API.
+var ldapConfigAttrs = []string{
+	"enabled", "connection_host", "connection_port", "connection_tls", "connection_tls_no_verify", "auth_username", "auth_password",
+	"user_bind_base_dn", "user_custom_filter", "user_id_attribute_names", "user_objectclass", "user_attribute_map_email",
+	"user_attribute_map_first_name", "user_attribute_map_last_name", "user_attribute_map_ldap_id", "groups_base_dn", "groups_finder_type",
+	"groups_member_attribute", "groups_objects", "groups_user_attribute", "force_no_page", "merge_new_users_by_email",
+	"default_new_user_role_ids", "default_new_user_group_ids", "auth_requires_role", "allow_direct_roles", "allow_normal_group_membership",
+	"alternate_email_login_allowed", "allow_roles_from_normal_groups", "groups_with_role_ids", "set_roles_from_groups", "user_attributes_with_ids",
+}
+
+func resourceLdapConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource updates the LDAP config in a Looker instance.",
+
+		CreateContext: resourceLdapConfigCreateOrUpdate,
+		ReadContext:   resourceLdapConfigRead,
+		UpdateContext: resourceLdapConfigCreateOrUpdate,
+		DeleteContext: resourceLdapConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLdapConfigImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Enable/Disable LDAP authentication for the server",
+			},
+			"connection_host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "LDAP server hostname",
+			},
+			"connection_port": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "LDAP host port",
+			},
+			"connection_tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use Transport Layer Security",
+			},
+			"connection_tls_no_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Do not verify peer when using TLS",
+			},
+			"auth_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Username for LDAP bind. Leave empty to bind anonymously",
+			},
+			"auth_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for the LDAP bind user. Looker does not return the password, so changes made outside of terraform are not detected",
+			},
+			"user_bind_base_dn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Distinguished name of LDAP node used as the base for user searches",
+			},
+			"user_custom_filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "(Optional) Custom RFC-2254 filter clause for use in finding user during login. Combined via 'and' with the other generated filter clauses",
+			},
+			"user_id_attribute_names": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name(s) of user record attributes used for matching user login id (comma separated list)",
+			},
+			"user_objectclass": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "(Optional) Name of user record objectclass used for finding user during login id",
+			},
+			"user_attribute_map_email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of user record attributes used to indicate email address field",
+			},
+			"user_attribute_map_first_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of user record attributes used to indicate first name",
+			},
+			"user_attribute_map_last_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of user record attributes used to indicate last name",
+			},
+			"user_attribute_map_ldap_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of user record attributes used to indicate unique record id",
+			},
+			"groups_base_dn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Base dn for finding groups in LDAP searches",
+			},
+			"groups_finder_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Identifier for a strategy for how Looker will search for groups in the LDAP server",
+			},
+			"groups_member_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "LDAP Group attribute that signifies the members of the groups. Most commonly 'member'",
+			},
+			"groups_objects": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Optional comma-separated list of supported LDAP objectclass for groups when doing groups searches",
+			},
+			"groups_user_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "LDAP Group attribute that signifies the user in a group. Most commonly 'dn'",
+			},
+			"force_no_page": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't attempt to do LDAP search result paging (RFC 2696) even if the LDAP server claims to support it",
+			},
+			"merge_new_users_by_email": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Merge first-time ldap login to existing user account by email addresses. When a user logs in for the first time via ldap this option will connect this user into their existing account by finding the account with a matching email address. Otherwise a new user account will be created for the user.",
+			},
+			"default_new_user_role_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Array of ids of roles that will be applied to new users the first time they login via LDAP",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_new_user_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Array of ids of groups that will be applied to new users the first time they login via LDAP",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"auth_requires_role": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Users will not be allowed to login at all unless a role for them is found in LDAP if set to true",
+			},
+			"allow_direct_roles": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allows roles to be directly assigned to LDAP auth'd users.",
+			},
+			"allow_normal_group_membership": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow LDAP auth'd users to be members of non-reflected Looker groups. If 'false', user will be removed from non-reflected groups on login.",
+			},
+			"alternate_email_login_allowed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow alternate email-based login via '/login/email' for admins and for specified users with the 'login_special_email' permission. This option is useful as a fallback during ldap setup, if ldap config problems occur later, or if you need to support some users who are not in your ldap directory. Looker email/password logins are always disabled for regular users when ldap is enabled.",
+			},
+			"allow_roles_from_normal_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "LDAP auth'd users will inherit roles from non-reflected Looker groups.",
+			},
+			"groups_with_role_ids": groupsWithRoleIDsSchema("LDAP"),
+			"set_roles_from_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set user roles in Looker based on groups from LDAP",
+			},
+			"user_attributes_with_ids": userAttributesWithIDsSchema("LDAP"),
+			"validate": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tests the config with the LDAP test endpoints of the Looker API before it is saved. The connection to the LDAP server and the authentication of the bind user are always tested. The user info test is run when `test_ldap_user` is set, and the user authentication test is run when `test_ldap_password` is also set. The result of each test is reported separately, and the config is not saved if any test fails",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"test_ldap_user": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The login id of a user to test the user info and user authentication with",
+						},
+						"test_ldap_password": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							RequiredWith: []string{"validate.0.test_ldap_user"},
+							Description:  "The password of the test user, used to test the user authentication",
+						},
+					},
+				},
+			},
+			"id": {
+				Description: "This is always `ldap`, as there is only one LDAP config in a Looker instance",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+		},
+	}
+}
+
+func resourceLdapConfigRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	cfg, err := api.LdapConfig(nil)
+	if errors.Is(err, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return apiDiags(err, "failed to read the LDAP config")
+	}
+
+	result := multierror.Append(
+		d.Set("enabled", cfg.Enabled),
+		d.Set("connection_host", cfg.ConnectionHost),
+		d.Set("connection_port", cfg.ConnectionPort),
+		d.Set("connection_tls", cfg.ConnectionTls),
+		d.Set("connection_tls_no_verify", cfg.ConnectionTlsNoVerify),
+		d.Set("auth_username", cfg.AuthUsername),
+		d.Set("user_bind_base_dn", cfg.UserBindBaseDn),
+		d.Set("user_custom_filter", cfg.UserCustomFilter),
+		d.Set("user_id_attribute_names", cfg.UserIdAttributeNames),
+		d.Set("user_objectclass", cfg.UserObjectclass),
+		d.Set("user_attribute_map_email", cfg.UserAttributeMapEmail),
+		d.Set("user_attribute_map_first_name", cfg.UserAttributeMapFirstName),
+		d.Set("user_attribute_map_last_name", cfg.UserAttributeMapLastName),
+		d.Set("user_attribute_map_ldap_id", cfg.UserAttributeMapLdapId),
+		d.Set("groups_base_dn", cfg.GroupsBaseDn),
+		d.Set("groups_finder_type", cfg.GroupsFinderType),
+		d.Set("groups_member_attribute", cfg.GroupsMemberAttribute),
+		d.Set("groups_objects", cfg.GroupsObjects),
+		d.Set("groups_user_attribute", cfg.GroupsUserAttribute),
+		d.Set("force_no_page", cfg.ForceNoPage),
+		d.Set("merge_new_users_by_email", cfg.MergeNewUsersByEmail),
+		d.Set("default_new_user_role_ids", flattenRoles(cfg.DefaultNewUserRoles)),
+		d.Set("default_new_user_group_ids", flattenGroups(cfg.DefaultNewUserGroups)),
+		d.Set("auth_requires_role", cfg.AuthRequiresRole),
+		d.Set("allow_direct_roles", cfg.AllowDirectRoles),
+		d.Set("allow_normal_group_membership", cfg.AllowNormalGroupMembership),
+		d.Set("alternate_email_login_allowed", cfg.AlternateEmailLoginAllowed),
+		d.Set("allow_roles_from_normal_groups", cfg.AllowRolesFromNormalGroups),
+		d.Set("groups_with_role_ids", flattenLdapGroupsWithRoleIDs(cfg.Groups)),
+		d.Set("set_roles_from_groups", cfg.SetRolesFromGroups),
+		d.Set("user_attributes_with_ids", flattenLdapUserAttributesWithIDs(cfg.UserAttributes)),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceLdapConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// existing mappings are matched by name, so that they are updated rather than replaced
+	current, err := api.LdapConfig(nil)
+	if err != nil && !errors.Is(err, sdk.ErrNotFound) {
+		return apiDiags(err, "failed to read the LDAP config")
+	}
+	groupIDs := make(map[string]*string)
+	if current.Groups != nil {
+		for _, g := range *current.Groups {
+			if g.Name != nil {
+				groupIDs[*g.Name] = g.Id
+			}
+		}
+	}
+
+	groupsWithRoleIDs := make([]sdk.LDAPGroupWrite, 0)
+	if vs, ok := d.GetOk("groups_with_role_ids"); ok {
+		for _, v := range vs.(*schema.Set).List() {
+			lgw := v.(map[string]interface{})
+			roleIDs, err := conv.SchemaSetToSliceString(lgw["role_ids"].(*schema.Set))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			groupsWithRoleIDs = append(groupsWithRoleIDs, sdk.LDAPGroupWrite{
+				Id:              groupIDs[lgw["name"].(string)],
+				LookerGroupName: conv.P(lgw["looker_group_name"].(string)),
+				Name:            conv.P(lgw["name"].(string)),
+				RoleIds:         conv.P(roleIDs),
+			})
+		}
+	}
+
+	userAttributesWithIDs := make([]sdk.LDAPUserAttributeWrite, 0)
+	if vs, ok := d.GetOk("user_attributes_with_ids"); ok {
+		for _, v := range vs.(*schema.Set).List() {
+			luaw := v.(map[string]interface{})
+			userAttributeIDs, err := conv.SchemaSetToSliceString(luaw["user_attribute_ids"].(*schema.Set))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			userAttributesWithIDs = append(userAttributesWithIDs, sdk.LDAPUserAttributeWrite{
+				Name:             conv.P(luaw["name"].(string)),
+				Required:         conv.P(luaw["required"].(bool)),
+				UserAttributeIds: conv.P(userAttributeIDs),
+			})
+		}
+	}
+
+	defaultNewUserGroupIDs, err := conv.SchemaSetToSliceString(d.Get("default_new_user_group_ids").(*schema.Set))
+	if err != nil {
+		return apiDiags(err, "failed to read default_new_user_group_ids")
+	}
+
+	defaultNewUserRoleIDs, err := conv.SchemaSetToSliceString(d.Get("default_new_user_role_ids").(*schema.Set))
+	if err != nil {
+		return apiDiags(err, "failed to read default_new_user_role_ids")
+	}
+
+	// attributes which looker sets a default for are only sent when they are set, so that looker keeps its defaults otherwise
+	cfg := sdk.WriteLDAPConfig{
+		Enabled:                    conv.P(d.Get("enabled").(bool)),
+		ConnectionHost:             conv.P(d.Get("connection_host").(string)),
+		ConnectionPort:             conv.P(d.Get("connection_port").(string)),
+		ConnectionTls:              conv.P(d.Get("connection_tls").(bool)),
+		ConnectionTlsNoVerify:      conv.P(d.Get("connection_tls_no_verify").(bool)),
+		AuthUsername:               conv.P(d.Get("auth_username").(string)),
+		AuthPassword:               conv.PString(d.Get("auth_password").(string)),
+		UserBindBaseDn:             conv.P(d.Get("user_bind_base_dn").(string)),
+		UserCustomFilter:           conv.P(d.Get("user_custom_filter").(string)),
+		UserIdAttributeNames:       conv.PString(d.Get("user_id_attribute_names").(string)),
+		UserObjectclass:            conv.PString(d.Get("user_objectclass").(string)),
+		UserAttributeMapEmail:      conv.P(d.Get("user_attribute_map_email").(string)),
+		UserAttributeMapFirstName:  conv.P(d.Get("user_attribute_map_first_name").(string)),
+		UserAttributeMapLastName:   conv.P(d.Get("user_attribute_map_last_name").(string)),
+		UserAttributeMapLdapId:     conv.PString(d.Get("user_attribute_map_ldap_id").(string)),
+		GroupsBaseDn:               conv.PString(d.Get("groups_base_dn").(string)),
+		GroupsFinderType:           conv.PString(d.Get("groups_finder_type").(string)),
+		GroupsMemberAttribute:      conv.PString(d.Get("groups_member_attribute").(string)),
+		GroupsObjects:              conv.PString(d.Get("groups_objects").(string)),
+		GroupsUserAttribute:        conv.PString(d.Get("groups_user_attribute").(string)),
+		ForceNoPage:                conv.P(d.Get("force_no_page").(bool)),
+		MergeNewUsersByEmail:       conv.P(d.Get("merge_new_users_by_email").(bool)),
+		DefaultNewUserGroupIds:     conv.P(defaultNewUserGroupIDs),
+		DefaultNewUserRoleIds:      conv.P(defaultNewUserRoleIDs),
+		AuthRequiresRole:           conv.P(d.Get("auth_requires_role").(bool)),
+		AllowDirectRoles:           conv.P(d.Get("allow_direct_roles").(bool)),
+		AllowNormalGroupMembership: conv.P(d.Get("allow_normal_group_membership").(bool)),
+		AlternateEmailLoginAllowed: conv.P(d.Get("alternate_email_login_allowed").(bool)),
+		AllowRolesFromNormalGroups: conv.P(d.Get("allow_roles_from_normal_groups").(bool)),
+		GroupsWithRoleIds:          conv.P(groupsWithRoleIDs),
+		SetRolesFromGroups:         conv.P(d.Get("set_roles_from_groups").(bool)),
+		UserAttributesWithIds:      conv.P(userAttributesWithIDs),
+	}
+
+	if vs := d.Get("validate").([]interface{}); len(vs) > 0 {
+		// an empty validate block only tests the connection and the bind user
+		validate, _ := vs[0].(map[string]interface{})
+		if diags := testLdapConfig(ctx, api, cfg, validate); diags.HasError() {
+			return diags
+		}
+	}
+
+	if _, err := api.UpdateLdapConfig(cfg, nil); err != nil {
+		return apiDiags(err, "failed to update the LDAP config", ldapConfigAttrs...)
+	}
+
+	d.SetId(ldapConfigID)
+
+	return resourceLdapConfigRead(ctx, d, c)
+}
+
+func resourceLdapConfigDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// looker validates the connection settings when they are changed, so they are left in place and only LDAP login and the
+	// mappings which grant access are removed
+	if _, err := api.UpdateLdapConfig(sdk.WriteLDAPConfig{
+		Enabled:                    conv.P(false),
+		DefaultNewUserGroupIds:     conv.P([]string{}),
+		DefaultNewUserRoleIds:      conv.P([]string{}),
+		AuthRequiresRole:           conv.P(false),
+		AllowDirectRoles:           conv.P(false),
+		AllowNormalGroupMembership: conv.P(false),
+		AlternateEmailLoginAllowed: conv.P(false),
+		AllowRolesFromNormalGroups: conv.P(false),
+		GroupsWithRoleIds:          conv.P([]sdk.LDAPGroupWrite{}),
+		SetRolesFromGroups:         conv.P(false),
+		UserAttributesWithIds:      conv.P([]sdk.LDAPUserAttributeWrite{}),
+	}, nil); err != nil && !errors.Is(err, sdk.ErrNotFound) {
+		return apiDiags(err, "failed to disable the LDAP config")
+	}
+
+	return nil
+}
+
+// resourceLdapConfigImport imports the LDAP config of the instance. There is only one LDAP config, so the id passed to import is ignored.
+func resourceLdapConfigImport(ctx context.Context, d *schema.ResourceData, c interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(ldapConfigID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// ldapConfigTest is a test of an LDAP config, run by one of the LDAP test endpoints of the Looker API.
+type ldapConfigTest struct {
+	name string
+	run  func(body sdk.WriteLDAPConfig, options *rtl.ApiSettings) (sdk.LDAPConfigTestResult, error)
+}
+
+// testLdapConfig tests the config with the LDAP test endpoints of the Looker API, and returns a diagnostic for each test that fails.
+// The tests which need a test user are only run when the user, and the password for the user authentication test, are set.
+func testLdapConfig(ctx context.Context, api *sdk.LookerSDK, cfg sdk.WriteLDAPConfig, validate map[string]interface{}) diag.Diagnostics {
+	tests := []ldapConfigTest{
+		{name: "connection", run: api.TestLdapConfigConnection},
+		{name: "auth", run: api.TestLdapConfigAuth},
+	}
+
+	if user, _ := validate["test_ldap_user"].(string); user != "" {
+		cfg.TestLdapUser = conv.P(user)
+		tests = append(tests, ldapConfigTest{name: "user info", run: api.TestLdapConfigUserInfo})
+
+		if password, _ := validate["test_ldap_password"].(string); password != "" {
+			cfg.TestLdapPassword = conv.P(password)
+			tests = append(tests, ldapConfigTest{name: "user auth", run: api.TestLdapConfigUserAuth})
+		}
+	}
+
+	var diags diag.Diagnostics
+	for _, test := range tests {
+		result, err := test.run(cfg, nil)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("LDAP %s test could not be run", test.name),
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("validate"),
+			})
+			continue
+		}
+
+		if result.Status != nil && *result.Status == "success" {
+			tflog.Info(ctx, "LDAP config test passed", map[string]interface{}{"test": test.name})
+			continue
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("LDAP %s test failed: %s", test.name, conv.Deref(result.Message)),
+			Detail:        ldapConfigTestDetail(result),
+			AttributePath: cty.GetAttrPath("validate"),
+		})
+	}
+
+	return diags
+}
+
+// ldapConfigTestDetail describes the issues found by an LDAP config test.
+func ldapConfigTestDetail(result sdk.LDAPConfigTestResult) string {
+	var lines []string
+	if result.Issues != nil {
+		for _, issue := range *result.Issues {
+			lines = append(lines, fmt.Sprintf("%s: %s", conv.Deref(issue.Severity), conv.Deref(issue.Message)))
+		}
+	}
+	if result.Details != nil && *result.Details != "" {
+		lines = append(lines, *result.Details)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func flattenLdapGroupsWithRoleIDs(lgws *[]sdk.LDAPGroupRead) []interface{} {
+	if lgws == nil {
+		return nil
+	}
+
+	groupsWithRoleIDs := make([]interface{}, len(*lgws))
+	for i, lgw := range *lgws {
+		groupsWithRoleIDs[i] = flattenGroupWithRoleIDs(lgw.Id, lgw.LookerGroupId, lgw.LookerGroupName, lgw.Name, lgw.Roles)
+	}
+
+	return groupsWithRoleIDs
+}
+
+func flattenLdapUserAttributesWithIDs(luaws *[]sdk.LDAPUserAttributeRead) []interface{} {
+	if luaws == nil {
+		return nil
+	}
+
+	userAttributesWithIDs := make([]interface{}, len(*luaws))
+	for i, luaw := range *luaws {
+		userAttributesWithIDs[i] = flattenUserAttributeWithIDs(luaw.Name, luaw.Required, luaw.UserAttributes)
+	}
+
+	return userAttributesWithIDs
+}
@@ -0,0 +1,233 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func testAccLdapConfig(customFilter, validate string) string {
+	return fmt.Sprintf(`
+	resource "looker_ldap_config" "test_acc" {
+		enabled           = false
+		connection_host   = "ldap.orange.com"
+		connection_port   = "636"
+		connection_tls    = true
+		auth_username     = "cn=looker,dc=orange,dc=com"
+		auth_password     = "test-acc-password"
+		user_bind_base_dn = "ou=people,dc=orange,dc=com"
+
+		user_custom_filter            = %q
+		user_attribute_map_email      = "mail"
+		user_attribute_map_first_name = "givenName"
+		user_attribute_map_last_name  = "sn"
+
+		groups_base_dn        = "ou=groups,dc=orange,dc=com"
+		set_roles_from_groups = true
+
+		groups_with_role_ids {
+			name              = "test-acc-analysts"
+			looker_group_name = "Test Acc Analysts"
+			role_ids          = ["2"]
+		}
+		%s
+	}
+	`, customFilter, validate)
+}
+
+func TestAccLookerLdapConfig(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_ldap_config")
+	defer stop() //nolint:errcheck
+
+	filter := "(memberOf=cn=looker,ou=groups,dc=orange,dc=com)"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckLdapConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLdapConfig("", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_ldap_config.test_acc", "id", "ldap"),
+					resource.TestCheckResourceAttr("looker_ldap_config.test_acc", "enabled", "false"),
+					resource.TestCheckResourceAttr("looker_ldap_config.test_acc", "user_id_attribute_names", "uid"),
+					resource.TestCheckResourceAttr("looker_ldap_config.test_acc", "groups_with_role_ids.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_ldap_config.test_acc", "groups_with_role_ids.*", map[string]string{
+						"id":              "4",
+						"looker_group_id": "31",
+						"name":            "test-acc-analysts",
+					}),
+				),
+			},
+			{
+				// the existing mapping of the group is updated, rather than replaced
+				Config: testAccLdapConfig(filter, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_ldap_config.test_acc", "user_custom_filter", filter),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_ldap_config.test_acc", "groups_with_role_ids.*", map[string]string{
+						"id":   "4",
+						"name": "test-acc-analysts",
+					}),
+				),
+			},
+			{
+				// the LDAP server cannot be reached from the Looker instance, so the config is not saved
+				Config:      testAccLdapConfig(filter, "validate {}"),
+				ExpectError: regexp.MustCompile("LDAP connection test failed: Cannot connect to LDAP server"),
+			},
+		},
+	})
+}
+
+// testAccCheckLdapConfigDestroy checks that LDAP login is disabled and its mappings are removed, and that the connection settings are
+// left in place.
+func testAccCheckLdapConfigDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	cfg, err := client.LdapConfig(nil)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Enabled == nil || *cfg.Enabled {
+		return errors.New("LDAP is still enabled")
+	}
+	if cfg.Groups != nil && len(*cfg.Groups) != 0 {
+		return fmt.Errorf("expected the group mappings to be removed, got %d", len(*cfg.Groups))
+	}
+	if cfg.ConnectionHost == nil || *cfg.ConnectionHost != "ldap.orange.com" {
+		return fmt.Errorf("expected the connection host to be left in place, got %v", cfg.ConnectionHost)
+	}
+
+	return nil
+}
+
+// newLdapConfigServer returns a fake Looker API which responds to the LDAP test endpoints with the given results. The paths of the
+// requests received by the server, other than the login of the client, are returned by calls.
+func newLdapConfigServer(t *testing.T, results map[string]string) (*lookerClient, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var paths []string
+
+	c := newFakeLookerServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasPrefix(r.URL.Path, "/api/4.0/ldap_config/test_") {
+			var body sdk.WriteLDAPConfig
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, results[strings.TrimPrefix(r.URL.Path, "/api/4.0/ldap_config/")])
+			return
+		}
+
+		fmt.Fprint(w, "{}")
+	}))
+
+	return c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, paths...)
+	}
+}
+
+func testLdapConfigResourceData(t *testing.T, validate []interface{}) *schema.ResourceData {
+	t.Helper()
+
+	return schema.TestResourceDataRaw(t, resourceLdapConfig().Schema, map[string]interface{}{
+		"enabled":                       true,
+		"connection_host":               "ldap.orange.com",
+		"connection_port":               "636",
+		"connection_tls":                true,
+		"auth_username":                 "cn=looker,dc=orange,dc=com",
+		"auth_password":                 "password",
+		"user_bind_base_dn":             "ou=people,dc=orange,dc=com",
+		"user_attribute_map_email":      "mail",
+		"user_attribute_map_first_name": "givenName",
+		"user_attribute_map_last_name":  "sn",
+		"validate":                      validate,
+	})
+}
+
+func TestLdapConfigValidate(t *testing.T) {
+	success := `{"status":"success","message":"OK"}`
+
+	t.Run("failed tests are reported separately and the config is not saved", func(t *testing.T) {
+		c, calls := newLdapConfigServer(t, map[string]string{
+			"test_connection": success,
+			"test_auth":       `{"status":"error","message":"Invalid credentials","issues":[{"severity":"error","message":"bind failed"}]}`,
+			"test_user_info":  success,
+			"test_user_auth":  `{"status":"error","message":"User auth failed"}`,
+		})
+
+		d := testLdapConfigResourceData(t, []interface{}{
+			map[string]interface{}{
+				"test_ldap_user":     "tina",
+				"test_ldap_password": "secret",
+			},
+		})
+		diags := resourceLdapConfigCreateOrUpdate(context.Background(), d, c)
+
+		if len(diags) != 2 {
+			t.Fatalf("expected a diagnostic for each of the 2 failed tests, got %v", diags)
+		}
+		if diags[0].Summary != "LDAP auth test failed: Invalid credentials" || diags[0].Detail != "error: bind failed" {
+			t.Errorf("unexpected diagnostic for the auth test: %s: %s", diags[0].Summary, diags[0].Detail)
+		}
+		if diags[1].Summary != "LDAP user auth test failed: User auth failed" {
+			t.Errorf("unexpected diagnostic for the user auth test: %s", diags[1].Summary)
+		}
+
+		for _, call := range calls() {
+			if call == "PATCH /api/4.0/ldap_config" {
+				t.Error("expected the config to not be saved when a test fails")
+			}
+		}
+		if d.Id() != "" {
+			t.Errorf("expected no id to be set, got %q", d.Id())
+		}
+	})
+
+	t.Run("tests which need a test user are skipped", func(t *testing.T) {
+		c, calls := newLdapConfigServer(t, map[string]string{
+			"test_connection": success,
+			"test_auth":       success,
+		})
+
+		d := testLdapConfigResourceData(t, []interface{}{map[string]interface{}{}})
+		if diags := resourceLdapConfigCreateOrUpdate(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		expected := []string{
+			"GET /api/4.0/ldap_config",
+			"PUT /api/4.0/ldap_config/test_connection",
+			"PUT /api/4.0/ldap_config/test_auth",
+			"PATCH /api/4.0/ldap_config",
+			"GET /api/4.0/ldap_config",
+		}
+		if actual := calls(); strings.Join(actual, ",") != strings.Join(expected, ",") {
+			t.Errorf("expected calls %v, got %v", expected, actual)
+		}
+		if d.Id() != ldapConfigID {
+			t.Errorf("expected id %q, got %q", ldapConfigID, d.Id())
+		}
+	})
+}
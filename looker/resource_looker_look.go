@@ -0,0 +1,310 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+var lookAttrs = []string{"title", "description", "folder_id", "public"}
+
+func resourceLook() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates a Look, which is a saved query, in a Looker instance. " +
+			"Queries are immutable in Looker, so a new query is created and the Look is updated to use it when the `query` block changes.",
+
+		CreateContext: resourceLookCreate,
+		ReadContext:   resourceLookRead,
+		UpdateContext: resourceLookUpdate,
+		DeleteContext: resourceLookDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The title of the Look, which must be unique in its folder",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the Look",
+			},
+			"folder_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the folder of the Look",
+			},
+			"public": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the Look can be viewed with its public url, without logging in to Looker",
+			},
+			"query": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The query of the Look. A new query is created when any of its fields change",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"model": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the LookML model of the query",
+						},
+						"view": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the explore of the query",
+						},
+						"fields": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The fields of the query, eg. `orders.status`",
+						},
+						"filters": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The filters of the query, as a map of field names to Looker filter expressions, eg. `{ \"orders.status\" = \"complete\" }`",
+						},
+						"sorts": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The sorts of the query, eg. `orders.count desc`",
+						},
+						"limit": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The row limit of the query. Looker applies its default limit of 500 rows when it is not set",
+						},
+						"pivots": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The fields of the query to pivot on",
+						},
+					},
+				},
+			},
+			"run_as_user_id": runAsSchema(),
+			"query_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the query of the Look",
+			},
+			"content_metadata_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the content metadata of the Look, used to manage access to the Look",
+			},
+			"short_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The short url of the Look, relative to the host of the Looker instance",
+			},
+		},
+	}
+}
+
+func resourceLookCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	queryID, diags := createLookQuery(api, d)
+	if diags.HasError() {
+		return diags
+	}
+
+	look, createErr := api.CreateLook(sdk.WriteLookWithQuery{
+		Title:       conv.P(d.Get("title").(string)),
+		Description: conv.PString(d.Get("description").(string)),
+		FolderId:    conv.P(d.Get("folder_id").(string)),
+		Public:      conv.P(d.Get("public").(bool)),
+		QueryId:     conv.P(queryID),
+	}, "", nil)
+	if createErr != nil {
+		return apiDiags(createErr, "failed to create look", lookAttrs...)
+	}
+
+	if look.Id == nil {
+		return diag.Errorf("look has missing id")
+	}
+	d.SetId(*look.Id)
+
+	return resourceLookRead(ctx, d, c)
+}
+
+func resourceLookRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	look, lookErr := api.Look(d.Id(), "", nil)
+	if errors.Is(lookErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if lookErr != nil {
+		return apiDiags(lookErr, "failed to read look")
+	}
+
+	// a deleted look is kept in the trash of the instance, from where it can be restored
+	if conv.Deref(look.Deleted) {
+		d.SetId("")
+		return nil
+	}
+
+	query, flattenErr := flattenLookQuery(look.Query)
+	if flattenErr != nil {
+		return diag.FromErr(flattenErr)
+	}
+
+	result := multierror.Append(
+		d.Set("title", look.Title),
+		d.Set("description", look.Description),
+		d.Set("folder_id", look.FolderId),
+		d.Set("public", look.Public),
+		d.Set("query", query),
+		d.Set("query_id", look.QueryId),
+		d.Set("content_metadata_id", look.ContentMetadataId),
+		d.Set("short_url", look.ShortUrl),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceLookUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	body := sdk.WriteLookWithQuery{
+		Title:       conv.P(d.Get("title").(string)),
+		Description: conv.P(d.Get("description").(string)),
+		FolderId:    conv.P(d.Get("folder_id").(string)),
+		Public:      conv.P(d.Get("public").(bool)),
+	}
+
+	// queries cannot be updated, so the look is pointed at a new query
+	if d.HasChange("query") {
+		queryID, diags := createLookQuery(api, d)
+		if diags.HasError() {
+			return diags
+		}
+		body.QueryId = conv.P(queryID)
+	}
+
+	if _, updateErr := api.UpdateLook(d.Id(), body, "", nil); updateErr != nil {
+		return apiDiags(updateErr, "failed to update look", lookAttrs...)
+	}
+
+	return resourceLookRead(ctx, d, c)
+}
+
+func resourceLookDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	_, delErr := api.DeleteLook(d.Id(), nil)
+	if !errors.Is(delErr, sdk.ErrNotFound) {
+		return apiDiags(delErr, "failed to delete look")
+	}
+
+	return nil
+}
+
+// createLookQuery creates the query in the query block of the look, and returns its id.
+func createLookQuery(api *sdk.LookerSDK, d *schema.ResourceData) (string, diag.Diagnostics) {
+	body, expandErr := expandLookQuery(d.Get("query").([]interface{}))
+	if expandErr != nil {
+		return "", diag.FromErr(expandErr)
+	}
+
+	query, createErr := api.CreateQuery(body, "", nil)
+	if createErr != nil {
+		return "", apiDiags(createErr, "failed to create the query of the look")
+	}
+
+	if query.Id == nil {
+		return "", diag.Errorf("query has missing id")
+	}
+
+	return *query.Id, nil
+}
+
+// expandLookQuery takes the query block of a look and maps it to the body of a query in the Looker API.
+func expandLookQuery(vs []interface{}) (sdk.WriteQuery, error) {
+	if len(vs) == 0 || vs[0] == nil {
+		return sdk.WriteQuery{}, errors.New("query block is missing")
+	}
+	q := vs[0].(map[string]interface{})
+
+	fields, err := conv.SchemaListToSliceString(q["fields"].([]interface{}))
+	if err != nil {
+		return sdk.WriteQuery{}, fmt.Errorf("fields: %w", err)
+	}
+	sorts, err := conv.SchemaListToSliceString(q["sorts"].([]interface{}))
+	if err != nil {
+		return sdk.WriteQuery{}, fmt.Errorf("sorts: %w", err)
+	}
+	pivots, err := conv.SchemaListToSliceString(q["pivots"].([]interface{}))
+	if err != nil {
+		return sdk.WriteQuery{}, fmt.Errorf("pivots: %w", err)
+	}
+
+	query := sdk.WriteQuery{
+		Model:  q["model"].(string),
+		View:   q["view"].(string),
+		Fields: conv.PSlices(fields),
+		Sorts:  conv.PSlices(sorts),
+		Pivots: conv.PSlices(pivots),
+	}
+
+	if filters := q["filters"].(map[string]interface{}); len(filters) > 0 {
+		query.Filters = &filters
+	}
+	if limit := q["limit"].(int); limit > 0 {
+		query.Limit = conv.P(strconv.Itoa(limit))
+	}
+
+	return query, nil
+}
+
+// flattenLookQuery takes the query of a look and maps it to the query block of the resource. This function returns a []interface{}
+// because the terraform aggregate type schema.TypeList expects a slice of attributes.
+func flattenLookQuery(query *sdk.Query) ([]interface{}, error) {
+	if query == nil {
+		return nil, nil
+	}
+
+	q := map[string]interface{}{
+		"model":   query.Model,
+		"view":    query.View,
+		"fields":  conv.Deref(query.Fields),
+		"filters": conv.Deref(query.Filters),
+		"sorts":   conv.Deref(query.Sorts),
+		"pivots":  conv.Deref(query.Pivots),
+	}
+
+	if query.Limit != nil && *query.Limit != "" {
+		limit, err := strconv.Atoi(*query.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit %q of query %s: %w", *query.Limit, conv.Deref(query.Id), err)
+		}
+		q["limit"] = limit
+	}
+
+	return []interface{}{q}, nil
+}
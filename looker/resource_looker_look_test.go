@@ -0,0 +1,119 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func TestAccLookerLook(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_look")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckLookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookConfig("test-acc-look", 100),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_look.test_acc", "id", "57"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "title", "test-acc-look"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "query_id", "1310"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "query.0.fields.#", "2"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "query.0.filters.orders.created_date", "7 days"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "query.0.limit", "100"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "content_metadata_id", "530"),
+				),
+			},
+			{
+				// the look is kept, and points to a new query as the limit has changed
+				Config: testAccLookConfig("test-acc-look-renamed", 50),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_look.test_acc", "id", "57"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "title", "test-acc-look-renamed"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "query_id", "1311"),
+					resource.TestCheckResourceAttr("looker_look.test_acc", "query.0.limit", "50"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLookConfig(title string, limit int) string {
+	return fmt.Sprintf(`
+	resource "looker_look" "test_acc" {
+		title       = %q
+		description = "Orders by status"
+		folder_id   = "1"
+
+		query {
+			model   = "thelook"
+			view    = "orders"
+			fields  = ["orders.status", "orders.count"]
+			filters = {
+				"orders.created_date" = "7 days"
+			}
+			sorts = ["orders.count desc"]
+			limit = %d
+		}
+	}
+	`, title, limit)
+}
+
+func testAccCheckLookDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "looker_look" {
+			continue
+		}
+
+		_, err := client.Look(r.Primary.ID, "", nil)
+		if errors.Is(err, sdk.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("look %s has not been deleted", r.Primary.ID)
+	}
+
+	return nil
+}
+
+func TestExpandLookQuery(t *testing.T) {
+	query, err := expandLookQuery([]interface{}{
+		map[string]interface{}{
+			"model":   "thelook",
+			"view":    "orders",
+			"fields":  []interface{}{"orders.status", "orders.count"},
+			"filters": map[string]interface{}{"orders.status": "complete"},
+			"sorts":   []interface{}{},
+			"pivots":  []interface{}{},
+			"limit":   0,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if query.Model != "thelook" || query.View != "orders" {
+		t.Errorf("expected thelook::orders, got %s::%s", query.Model, query.View)
+	}
+	if query.Fields == nil || len(*query.Fields) != 2 {
+		t.Errorf("expected 2 fields, got %v", query.Fields)
+	}
+	if query.Filters == nil || (*query.Filters)["orders.status"] != "complete" {
+		t.Errorf("expected the orders.status filter, got %v", query.Filters)
+	}
+	// unset attributes are omitted, so that Looker applies its defaults
+	if query.Sorts != nil || query.Pivots != nil || query.Limit != nil {
+		t.Errorf("expected no sorts, pivots and limit, got %v, %v and %v", query.Sorts, query.Pivots, query.Limit)
+	}
+}
@@ -51,7 +51,7 @@ func resourceModelSet() *schema.Resource {
 }
 
 func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	models, ok := d.Get("models").(*schema.Set)
 	if !ok {
@@ -68,7 +68,7 @@ func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, c inter
 		Models: conv.PSlices(modelsSlice),
 	}, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiags(err, "failed to create model set", "name", "models")
 	}
 
 	if modelSet.Id == nil {
@@ -80,7 +80,7 @@ func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, c inter
 }
 
 func resourceModelSetRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	modelSet, err := api.ModelSet(d.Id(), "id,name,models", nil)
 	if errors.Is(err, sdk.ErrNotFound) {
@@ -101,7 +101,7 @@ func resourceModelSetRead(ctx context.Context, d *schema.ResourceData, c interfa
 }
 
 func resourceModelSetUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	models, ok := d.Get("models").(*schema.Set)
 	if !ok {
@@ -122,14 +122,14 @@ func resourceModelSetUpdate(ctx context.Context, d *schema.ResourceData, c inter
 		nil,
 	)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiags(err, "failed to update model set", "name", "models")
 	}
 
 	return resourceModelSetRead(ctx, d, c)
 }
 
 func resourceModelSetDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, err := api.DeleteModelSet(d.Id(), nil)
 	if !errors.Is(err, sdk.ErrNotFound) {
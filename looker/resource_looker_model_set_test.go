@@ -88,7 +88,7 @@ func testAccModelSet(modelSetResource string, expectedModelsSets []string) resou
 			return errors.New("model set ID is not set")
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		modelSet, err := client.ModelSet(modelSetRes.Primary.ID, "", nil)
 		if err != nil {
@@ -0,0 +1,466 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+// oidcConfigID is the id of the looker_oidc_config resource. There is only one OIDC config in a Looker instance.
+const oidcConfigID = "oidc"
+
+func resourceOidcConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource updates the OpenID Connect config in a Looker instance. Destroying the resource disables OIDC login and removes the mappings which grant access, and leaves the settings of the OpenID Provider in place.",
+
+		CreateContext: resourceOidcConfigCreateOrUpdate,
+		ReadContext:   resourceOidcConfigRead,
+		UpdateContext: resourceOidcConfigCreateOrUpdate,
+		DeleteContext: resourceOidcConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOidcConfigImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Enable/Disable OIDC authentication for the server",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "OpenID Provider Issuer",
+			},
+			"authorization_endpoint": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "OpenID Provider Authorization Url",
+			},
+			"token_endpoint": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "OpenID Provider Token Url",
+			},
+			"userinfo_endpoint": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "OpenID Provider User Information Url",
+			},
+			"identifier": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Relying Party Identifier (provided by OpenID Provider)",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Relying Party Secret (provided by OpenID Provider). Looker does not return the secret, so changes made outside of terraform are not detected",
+			},
+			"audience": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "OpenID Provider Audience",
+			},
+			"scopes": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Array of scopes to request. Defaults to the scopes set by Looker, which are `openid`, `email` and `profile`",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"user_attribute_map_email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of user record attributes used to indicate email address field",
+			},
+			"user_attribute_map_first_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of user record attributes used to indicate first name",
+			},
+			"user_attribute_map_last_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of user record attributes used to indicate last name",
+			},
+			"new_user_migration_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Merge first-time oidc login to existing user account by email addresses. When a user logs in for the first time via oidc this option will connect this user into their existing account by finding the account with a matching email address by testing the given types of credentials for existing users. Otherwise a new user account will be created for the user.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+						"email", "ldap", "saml", "google",
+					}, false)),
+				},
+			},
+			"default_new_user_role_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Array of ids of roles that will be applied to new users the first time they login via OIDC",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_new_user_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Array of ids of groups that will be applied to new users the first time they login via OIDC",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"auth_requires_role": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Users will not be allowed to login at all unless a role for them is found in OIDC if set to true",
+			},
+			"allow_direct_roles": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allows roles to be directly assigned to OIDC auth'd users.",
+			},
+			"allow_normal_group_membership": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow OIDC auth'd users to be members of non-reflected Looker groups. If 'false', user will be removed from non-reflected groups on login.",
+			},
+			"alternate_email_login_allowed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow alternate email-based login via '/login/email' for admins and for specified users with the 'login_special_email' permission. This option is useful as a fallback during OIDC setup, if OIDC config problems occur later, or if you need to support some users who are not in your OIDC directory. Looker email/password logins are always disabled for regular users when OIDC is enabled.",
+			},
+			"allow_roles_from_normal_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "OIDC auth'd users will inherit roles from non-reflected Looker groups.",
+			},
+			"groups_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Name of user record attributes used to indicate groups",
+			},
+			"groups_with_role_ids": groupsWithRoleIDsSchema("OIDC"),
+			"set_roles_from_groups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set user roles in Looker based on groups from OIDC",
+			},
+			"user_attributes_with_ids": userAttributesWithIDsSchema("OIDC"),
+			"id": {
+				Description: "This is always `oidc`, as there is only one OIDC config in a Looker instance",
+				Computed:    true,
+				Type:        schema.TypeString,
+			},
+		},
+	}
+}
+
+func resourceOidcConfigRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	cfg, err := api.OidcConfig(nil)
+	if errors.Is(err, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := multierror.Append(
+		d.Set("enabled", cfg.Enabled),
+		d.Set("issuer", cfg.Issuer),
+		d.Set("authorization_endpoint", cfg.AuthorizationEndpoint),
+		d.Set("token_endpoint", cfg.TokenEndpoint),
+		d.Set("userinfo_endpoint", cfg.UserinfoEndpoint),
+		d.Set("identifier", cfg.Identifier),
+		d.Set("audience", cfg.Audience),
+		d.Set("scopes", cfg.Scopes),
+		d.Set("user_attribute_map_email", cfg.UserAttributeMapEmail),
+		d.Set("user_attribute_map_first_name", cfg.UserAttributeMapFirstName),
+		d.Set("user_attribute_map_last_name", cfg.UserAttributeMapLastName),
+		d.Set("default_new_user_role_ids", flattenRoles(cfg.DefaultNewUserRoles)),
+		d.Set("default_new_user_group_ids", flattenGroups(cfg.DefaultNewUserGroups)),
+		d.Set("auth_requires_role", cfg.AuthRequiresRole),
+		d.Set("allow_direct_roles", cfg.AllowDirectRoles),
+		d.Set("allow_normal_group_membership", cfg.AllowNormalGroupMembership),
+		d.Set("alternate_email_login_allowed", cfg.AlternateEmailLoginAllowed),
+		d.Set("allow_roles_from_normal_groups", cfg.AllowRolesFromNormalGroups),
+		d.Set("groups_attribute", cfg.GroupsAttribute),
+		d.Set("groups_with_role_ids", flattenOidcGroupsWithRoleIDs(cfg.Groups)),
+		d.Set("set_roles_from_groups", cfg.SetRolesFromGroups),
+		d.Set("user_attributes_with_ids", flattenOidcUserAttributesWithIDs(cfg.UserAttributes)),
+	)
+
+	if cfg.NewUserMigrationTypes != nil && *cfg.NewUserMigrationTypes != "" {
+		result = multierror.Append(result, d.Set("new_user_migration_types", strings.Split(*cfg.NewUserMigrationTypes, ",")))
+	}
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceOidcConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// existing mappings are matched by name, so that they are updated rather than replaced
+	current, err := api.OidcConfig(nil)
+	if err != nil && !errors.Is(err, sdk.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	groupIDs := make(map[string]*string)
+	if current.Groups != nil {
+		for _, g := range *current.Groups {
+			if g.Name != nil {
+				groupIDs[*g.Name] = g.Id
+			}
+		}
+	}
+
+	groupsWithRoleIDs := make([]sdk.OIDCGroupWrite, 0)
+	if vs, ok := d.GetOk("groups_with_role_ids"); ok {
+		for _, v := range vs.(*schema.Set).List() {
+			ogw := v.(map[string]interface{})
+			roleIDs, err := conv.SchemaSetToSliceString(ogw["role_ids"].(*schema.Set))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			groupsWithRoleIDs = append(groupsWithRoleIDs, sdk.OIDCGroupWrite{
+				Id:              groupIDs[ogw["name"].(string)],
+				LookerGroupName: conv.P(ogw["looker_group_name"].(string)),
+				Name:            conv.P(ogw["name"].(string)),
+				RoleIds:         conv.P(roleIDs),
+			})
+		}
+	}
+
+	userAttributesWithIDs := make([]sdk.OIDCUserAttributeWrite, 0)
+	if vs, ok := d.GetOk("user_attributes_with_ids"); ok {
+		for _, v := range vs.(*schema.Set).List() {
+			ouaw := v.(map[string]interface{})
+			userAttributeIDs, err := conv.SchemaSetToSliceString(ouaw["user_attribute_ids"].(*schema.Set))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			userAttributesWithIDs = append(userAttributesWithIDs, sdk.OIDCUserAttributeWrite{
+				Name:             conv.P(ouaw["name"].(string)),
+				Required:         conv.P(ouaw["required"].(bool)),
+				UserAttributeIds: conv.P(userAttributeIDs),
+			})
+		}
+	}
+
+	newUserMigrationTypes, err := conv.SchemaSetToSliceString(d.Get("new_user_migration_types").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defaultNewUserGroupIDs, err := conv.SchemaSetToSliceString(d.Get("default_new_user_group_ids").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	defaultNewUserRoleIDs, err := conv.SchemaSetToSliceString(d.Get("default_new_user_role_ids").(*schema.Set))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cfg := sdk.WriteOIDCConfig{
+		Enabled:                    conv.P(d.Get("enabled").(bool)),
+		Issuer:                     conv.P(d.Get("issuer").(string)),
+		AuthorizationEndpoint:      conv.P(d.Get("authorization_endpoint").(string)),
+		TokenEndpoint:              conv.P(d.Get("token_endpoint").(string)),
+		UserinfoEndpoint:           conv.P(d.Get("userinfo_endpoint").(string)),
+		Identifier:                 conv.P(d.Get("identifier").(string)),
+		Secret:                     conv.P(d.Get("secret").(string)),
+		Audience:                   conv.P(d.Get("audience").(string)),
+		UserAttributeMapEmail:      conv.P(d.Get("user_attribute_map_email").(string)),
+		UserAttributeMapFirstName:  conv.P(d.Get("user_attribute_map_first_name").(string)),
+		UserAttributeMapLastName:   conv.P(d.Get("user_attribute_map_last_name").(string)),
+		NewUserMigrationTypes:      conv.P(strings.Join(newUserMigrationTypes, ",")),
+		DefaultNewUserGroupIds:     conv.P(defaultNewUserGroupIDs),
+		DefaultNewUserRoleIds:      conv.P(defaultNewUserRoleIDs),
+		AuthRequiresRole:           conv.P(d.Get("auth_requires_role").(bool)),
+		AllowDirectRoles:           conv.P(d.Get("allow_direct_roles").(bool)),
+		AllowNormalGroupMembership: conv.P(d.Get("allow_normal_group_membership").(bool)),
+		AlternateEmailLoginAllowed: conv.P(d.Get("alternate_email_login_allowed").(bool)),
+		AllowRolesFromNormalGroups: conv.P(d.Get("allow_roles_from_normal_groups").(bool)),
+		GroupsAttribute:            conv.P(d.Get("groups_attribute").(string)),
+		GroupsWithRoleIds:          conv.P(groupsWithRoleIDs),
+		SetRolesFromGroups:         conv.P(d.Get("set_roles_from_groups").(bool)),
+		UserAttributesWithIds:      conv.P(userAttributesWithIDs),
+	}
+
+	// the scopes are only sent when they are set, so that looker keeps its default scopes otherwise
+	if vs, ok := d.GetOk("scopes"); ok {
+		scopes, err := conv.SchemaSetToSliceString(vs.(*schema.Set))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		cfg.Scopes = conv.P(scopes)
+	}
+
+	if _, err := api.UpdateOidcConfig(cfg, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(oidcConfigID)
+
+	return resourceOidcConfigRead(ctx, d, c)
+}
+
+func resourceOidcConfigDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := c.(*lookerClient).LookerSDK
+
+	// looker requires the issuer, endpoints and client credentials of the OpenID Provider, so they are left in place and OIDC can be
+	// enabled again from the Looker UI. Only OIDC login and the mappings which grant access are removed
+	if _, err := api.UpdateOidcConfig(sdk.WriteOIDCConfig{
+		Enabled:                    conv.P(false),
+		DefaultNewUserGroupIds:     conv.P([]string{}),
+		DefaultNewUserRoleIds:      conv.P([]string{}),
+		AuthRequiresRole:           conv.P(false),
+		AllowDirectRoles:           conv.P(false),
+		AllowNormalGroupMembership: conv.P(false),
+		AlternateEmailLoginAllowed: conv.P(false),
+		AllowRolesFromNormalGroups: conv.P(false),
+		GroupsWithRoleIds:          conv.P([]sdk.OIDCGroupWrite{}),
+		SetRolesFromGroups:         conv.P(false),
+		UserAttributesWithIds:      conv.P([]sdk.OIDCUserAttributeWrite{}),
+	}, nil); err != nil && !errors.Is(err, sdk.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceOidcConfigImport imports the OIDC config of the instance. There is only one OIDC config, so the id passed to import is ignored.
+func resourceOidcConfigImport(ctx context.Context, d *schema.ResourceData, c interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(oidcConfigID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// groupsWithRoleIDsSchema is the schema of the mappings between the groups of an identity provider and Looker roles. Each mapping is
+// identified by the name of the group in the identity provider.
+func groupsWithRoleIDsSchema(idp string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: fmt.Sprintf("Array of mappings between %s Groups and arrays of Looker Role ids. Each mapping is identified by the name of the group in %s", idp, idp),
+		Set:         hashGroupWithRoleIDs,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Unique Id",
+				},
+				"looker_group_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Unique Id of group in Looker",
+				},
+				"looker_group_name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of group in Looker",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: fmt.Sprintf("Name of group in %s", idp),
+				},
+				"role_ids": {
+					Type:        schema.TypeSet,
+					Required:    true,
+					Description: "Looker Role Ids",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// userAttributesWithIDsSchema is the schema of the mappings between the user attributes of an identity provider and Looker user attributes.
+func userAttributesWithIDsSchema(idp string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: fmt.Sprintf("Array of mappings between %s User Attributes and arrays of Looker User Attribute ids", idp),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: fmt.Sprintf("Name of User Attribute in %s", idp),
+				},
+				"required": {
+					Type:        schema.TypeBool,
+					Required:    true,
+					Description: fmt.Sprintf("Required to be in %s assertion for login to be allowed to succeed", idp),
+				},
+				"user_attribute_ids": {
+					Type:        schema.TypeSet,
+					Required:    true,
+					Description: "Looker User Attribute Ids",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// hashGroupWithRoleIDs identifies a mapping between groups by the name of the group in the identity provider. The computed ids of
+// the mapping are not part of the hash, so that a mapping in the config matches the same mapping in the state.
+func hashGroupWithRoleIDs(v interface{}) int {
+	return schema.HashString(v.(map[string]interface{})["name"])
+}
+
+func flattenOidcGroupsWithRoleIDs(ogws *[]sdk.OIDCGroupRead) []interface{} {
+	if ogws == nil {
+		return nil
+	}
+
+	groupsWithRoleIDs := make([]interface{}, len(*ogws))
+	for i, ogw := range *ogws {
+		groupsWithRoleIDs[i] = flattenGroupWithRoleIDs(ogw.Id, ogw.LookerGroupId, ogw.LookerGroupName, ogw.Name, ogw.Roles)
+	}
+
+	return groupsWithRoleIDs
+}
+
+func flattenOidcUserAttributesWithIDs(ouaws *[]sdk.OIDCUserAttributeRead) []interface{} {
+	if ouaws == nil {
+		return nil
+	}
+
+	userAttributesWithIDs := make([]interface{}, len(*ouaws))
+	for i, ouaw := range *ouaws {
+		userAttributesWithIDs[i] = flattenUserAttributeWithIDs(ouaw.Name, ouaw.Required, ouaw.UserAttributes)
+	}
+
+	return userAttributesWithIDs
+}
@@ -0,0 +1,184 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func testAccOidcConfig(audience string) string {
+	return fmt.Sprintf(`
+	resource "looker_oidc_config" "test_acc" {
+		enabled                = false
+		issuer                 = "https://idp.orange.com"
+		authorization_endpoint = "https://idp.orange.com/oauth2/authorize"
+		token_endpoint         = "https://idp.orange.com/oauth2/token"
+		userinfo_endpoint      = "https://idp.orange.com/oauth2/userinfo"
+		identifier             = "test-acc-client"
+		secret                 = "test-acc-secret"
+		audience               = %q
+
+		user_attribute_map_email      = "email"
+		user_attribute_map_first_name = "given_name"
+		user_attribute_map_last_name  = "family_name"
+
+		groups_attribute      = "groups"
+		set_roles_from_groups = true
+
+		groups_with_role_ids {
+			name              = "test-acc-analysts"
+			looker_group_name = "Test Acc Analysts"
+			role_ids          = ["2"]
+		}
+	}
+	`, audience)
+}
+
+func TestAccLookerOidcConfig(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_oidc_config")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckOidcConfigDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOidcConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_oidc_config.test_acc", "id", "oidc"),
+					resource.TestCheckResourceAttr("looker_oidc_config.test_acc", "enabled", "false"),
+					resource.TestCheckResourceAttr("looker_oidc_config.test_acc", "scopes.#", "3"),
+					resource.TestCheckResourceAttr("looker_oidc_config.test_acc", "groups_with_role_ids.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_oidc_config.test_acc", "groups_with_role_ids.*", map[string]string{
+						"id":              "4",
+						"looker_group_id": "31",
+						"name":            "test-acc-analysts",
+					}),
+				),
+			},
+			{
+				// the existing mapping of the group is updated, rather than replaced
+				Config: testAccOidcConfig("test-acc-audience"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_oidc_config.test_acc", "audience", "test-acc-audience"),
+					resource.TestCheckTypeSetElemNestedAttrs("looker_oidc_config.test_acc", "groups_with_role_ids.*", map[string]string{
+						"id":   "4",
+						"name": "test-acc-analysts",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckOidcConfigDestroy checks that OIDC login is disabled and its mappings are removed, and that the settings of the OpenID
+// Provider are left in place.
+func testAccCheckOidcConfigDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	cfg, err := client.OidcConfig(nil)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Enabled == nil || *cfg.Enabled {
+		return errors.New("OIDC is still enabled")
+	}
+	if cfg.Groups != nil && len(*cfg.Groups) != 0 {
+		return fmt.Errorf("expected the group mappings to be removed, got %d", len(*cfg.Groups))
+	}
+	if cfg.Issuer == nil || *cfg.Issuer != "https://idp.orange.com" {
+		return fmt.Errorf("expected the issuer to be left in place, got %v", cfg.Issuer)
+	}
+
+	return nil
+}
+
+func TestOidcConfigCreateMatchesGroupsByName(t *testing.T) {
+	var written sdk.WriteOIDCConfig
+	c := newFakeLookerServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			// the instance already maps the Looker Viewers group
+			json.NewEncoder(w).Encode(sdk.OIDCConfig{ //nolint:errcheck
+				Groups: &[]sdk.OIDCGroupRead{{
+					Id:              conv.P("7"),
+					Name:            conv.P("Looker Viewers"),
+					LookerGroupName: conv.P("Viewers"),
+				}},
+			})
+		case http.MethodPatch:
+			if err := json.NewDecoder(r.Body).Decode(&written); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, "{}")
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+
+	d := schema.TestResourceDataRaw(t, resourceOidcConfig().Schema, map[string]interface{}{
+		"enabled":                       true,
+		"issuer":                        "https://mydomain.okta.com",
+		"authorization_endpoint":        "https://mydomain.okta.com/oauth2/v1/authorize",
+		"token_endpoint":                "https://mydomain.okta.com/oauth2/v1/token",
+		"userinfo_endpoint":             "https://mydomain.okta.com/oauth2/v1/userinfo",
+		"identifier":                    "client",
+		"secret":                        "secret",
+		"user_attribute_map_email":      "email",
+		"user_attribute_map_first_name": "given_name",
+		"user_attribute_map_last_name":  "family_name",
+		"groups_with_role_ids": []interface{}{
+			map[string]interface{}{
+				"name":              "Looker Viewers",
+				"looker_group_name": "Viewers",
+				"role_ids":          []interface{}{"1"},
+			},
+			map[string]interface{}{
+				"name":              "Looker Admins",
+				"looker_group_name": "Admins",
+				"role_ids":          []interface{}{"2"},
+			},
+		},
+	})
+	if diags := resourceOidcConfigCreateOrUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("failed to create oidc config: %v", diags)
+	}
+
+	if d.Id() != oidcConfigID {
+		t.Errorf("expected id %q, got %q", oidcConfigID, d.Id())
+	}
+	if written.Scopes != nil {
+		t.Errorf("expected scopes to not be sent when they are not set, got %v", *written.Scopes)
+	}
+	if written.GroupsWithRoleIds == nil || len(*written.GroupsWithRoleIds) != 2 {
+		t.Fatalf("expected 2 groups to be written, got %v", written.GroupsWithRoleIds)
+	}
+	for _, g := range *written.GroupsWithRoleIds {
+		switch *g.Name {
+		case "Looker Viewers":
+			if g.Id == nil || *g.Id != "7" {
+				t.Errorf("expected the existing mapping of %q to keep id 7, got %v", *g.Name, g.Id)
+			}
+		case "Looker Admins":
+			if g.Id != nil {
+				t.Errorf("expected the new mapping of %q to have no id, got %q", *g.Name, *g.Id)
+			}
+		default:
+			t.Errorf("unexpected group %q", *g.Name)
+		}
+	}
+}
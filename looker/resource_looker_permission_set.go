@@ -50,7 +50,7 @@ func resourcePermissionSet() *schema.Resource {
 }
 
 func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	permissions, ok := d.Get("permissions").(*schema.Set)
 	if !ok {
@@ -70,7 +70,7 @@ func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, c
 		nil,
 	)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiags(err, "failed to create permission set", "name", "permissions")
 	}
 
 	if permissionSet.Id == nil {
@@ -82,7 +82,7 @@ func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, c
 }
 
 func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	permissionSet, err := api.PermissionSet(d.Id(), "id,name,permissions", nil)
 	if errors.Is(err, sdk.ErrNotFound) {
@@ -103,7 +103,7 @@ func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, c in
 }
 
 func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	permissions, ok := d.Get("permissions").(*schema.Set)
 	if !ok {
@@ -124,14 +124,14 @@ func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, c
 		nil,
 	)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiags(err, "failed to update permission set", "name", "permissions")
 	}
 
 	return resourcePermissionSetRead(ctx, d, c)
 }
 
 func resourcePermissionSetDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, err := api.DeletePermissionSet(d.Id(), nil)
 	if !errors.Is(err, sdk.ErrNotFound) {
@@ -88,7 +88,7 @@ func testAccPermissionSet(permSetResource string, expectedPermSets []string) res
 			return errors.New("permission set ID is not set")
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		permSet, err := client.PermissionSet(permSetRes.Primary.ID, "", nil)
 		if err != nil {
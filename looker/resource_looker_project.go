@@ -0,0 +1,204 @@
+package looker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+func resourceProject() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates a LookML project in a Looker instance and configures its git settings. Projects cannot be deleted using the Looker API, destroying this resource only removes it from the terraform state.",
+
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the project. This is also the id of the project",
+			},
+			"git_remote_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The git remote repository url of the project, eg. `git@github.com:my-org/my-project.git`",
+			},
+			"git_service_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the git service provider, eg. `github`, `gitlab` or `bitbucket`. Looker detects the git service from the remote url if this is not set",
+			},
+			"git_production_branch_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The git branch that is deployed to production",
+			},
+			"pull_request_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     string(sdk.PullRequestMode_Off),
+				Description: "The git pull request policy for the project, one of `off`, `links`, `recommended` or `required`",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					string(sdk.PullRequestMode_Off),
+					string(sdk.PullRequestMode_Links),
+					string(sdk.PullRequestMode_Recommended),
+					string(sdk.PullRequestMode_Required),
+				}, false)),
+			},
+			"validation_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether LookML must be validated before it can be committed",
+			},
+			"uses_git": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the project is configured with a git repository",
+			},
+		},
+	}
+}
+
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	devErr := withDevWorkspace(c.(*lookerClient), func(api *sdk.LookerSDK) error {
+		project, projectErr := api.CreateProject(sdk.WriteProject{
+			Name: conv.PString(name),
+		}, nil)
+		if projectErr != nil {
+			return projectErr
+		}
+
+		if project.Id == nil {
+			return errors.New("project has missing id")
+		}
+		d.SetId(*project.Id)
+
+		// git settings cannot be set when a project is created
+		_, updateErr := api.UpdateProject(d.Id(), buildProjectInput(d), "", nil)
+		return updateErr
+	})
+	if devErr != nil {
+		return diag.FromErr(devErr)
+	}
+
+	return resourceProjectRead(ctx, d, c)
+}
+
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	var project sdk.Project
+	devErr := withDevWorkspace(c.(*lookerClient), func(api *sdk.LookerSDK) error {
+		var projectErr error
+		project, projectErr = api.Project(d.Id(), "", nil)
+		return projectErr
+	})
+	if errors.Is(devErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if devErr != nil {
+		return diag.FromErr(devErr)
+	}
+
+	var pullRequestMode string
+	if project.PullRequestMode != nil {
+		pullRequestMode = string(*project.PullRequestMode)
+	}
+
+	result := multierror.Append(
+		d.Set("name", project.Name),
+		d.Set("git_remote_url", project.GitRemoteUrl),
+		d.Set("git_service_name", project.GitServiceName),
+		d.Set("git_production_branch_name", project.GitProductionBranchName),
+		d.Set("pull_request_mode", pullRequestMode),
+		d.Set("validation_required", project.ValidationRequired),
+		d.Set("uses_git", project.UsesGit),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	devErr := withDevWorkspace(c.(*lookerClient), func(api *sdk.LookerSDK) error {
+		_, updateErr := api.UpdateProject(d.Id(), buildProjectInput(d), "", nil)
+		return updateErr
+	})
+	if devErr != nil {
+		return diag.FromErr(devErr)
+	}
+
+	return resourceProjectRead(ctx, d, c)
+}
+
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "project has not been deleted",
+			Detail:   "Projects cannot be deleted using the Looker API. The project " + d.Id() + " has been removed from the terraform state, but still exists in Looker.",
+		},
+	}
+}
+
+func buildProjectInput(d *schema.ResourceData) sdk.WriteProject {
+	return sdk.WriteProject{
+		GitRemoteUrl:            conv.PString(d.Get("git_remote_url").(string)),
+		GitServiceName:          conv.PString(d.Get("git_service_name").(string)),
+		GitProductionBranchName: conv.PString(d.Get("git_production_branch_name").(string)),
+		PullRequestMode:         conv.P(sdk.PullRequestMode(d.Get("pull_request_mode").(string))),
+		ValidationRequired:      conv.PBool(d.Get("validation_required").(bool)),
+	}
+}
+
+// workspaceLockKey is the key of the lock which serialises the requests made in the dev workspace.
+const workspaceLockKey = "workspace"
+
+// withDevWorkspace runs fn with the API session of the project resources switched to the dev workspace, which is required to create
+// and configure projects. The session is switched back to the production workspace once fn returns. The workspace is shared by all
+// requests of the session, so the calls are serialised, and the session is separate from the session of the other resources.
+func withDevWorkspace(c *lookerClient, fn func(api *sdk.LookerSDK) error) error {
+	c.locks.Lock(workspaceLockKey)
+	defer c.locks.Unlock(workspaceLockKey)
+
+	api := c.workspace
+
+	session, sessionErr := api.Session(nil)
+	if sessionErr != nil {
+		return sessionErr
+	}
+
+	if session.WorkspaceId != nil && *session.WorkspaceId == "dev" {
+		return fn(api)
+	}
+
+	if _, devErr := api.UpdateSession(sdk.WriteApiSession{WorkspaceId: conv.P("dev")}, nil); devErr != nil {
+		return devErr
+	}
+
+	fnErr := fn(api)
+
+	_, prodErr := api.UpdateSession(sdk.WriteApiSession{WorkspaceId: conv.P("production")}, nil)
+	if fnErr != nil {
+		// the error returned by fn is the most relevant, and is returned unwrapped so that callers can check for sdk.ErrNotFound
+		return fnErr
+	}
+
+	return prodErr
+}
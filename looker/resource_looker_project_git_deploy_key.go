@@ -0,0 +1,95 @@
+package looker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceProjectGitDeployKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates the git deploy key of a LookML project, if one does not exist already, and exposes the public key so it can be registered with the git service. Deploy keys cannot be deleted using the Looker API, destroying this resource only removes it from the terraform state.",
+
+		CreateContext: resourceProjectGitDeployKeyCreate,
+		ReadContext:   resourceProjectGitDeployKeyRead,
+		DeleteContext: resourceProjectGitDeployKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the project",
+			},
+			"public_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public key of the deploy key, in OpenSSH format",
+			},
+		},
+	}
+}
+
+func resourceProjectGitDeployKeyCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	projectID := d.Get("project_id").(string)
+	devErr := withDevWorkspace(c.(*lookerClient), func(api *sdk.LookerSDK) error {
+		// a project only has one deploy key, so an existing key is reused
+		key, keyErr := api.GitDeployKey(projectID, nil)
+		if keyErr != nil && !errors.Is(keyErr, sdk.ErrNotFound) {
+			return keyErr
+		}
+		if key != "" {
+			return nil
+		}
+
+		_, createErr := api.CreateGitDeployKey(projectID, nil)
+		return createErr
+	})
+	if devErr != nil {
+		return diag.FromErr(devErr)
+	}
+
+	d.SetId(projectID)
+
+	return resourceProjectGitDeployKeyRead(ctx, d, c)
+}
+
+func resourceProjectGitDeployKeyRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	var key string
+	devErr := withDevWorkspace(c.(*lookerClient), func(api *sdk.LookerSDK) error {
+		var keyErr error
+		key, keyErr = api.GitDeployKey(d.Id(), nil)
+		return keyErr
+	})
+	if errors.Is(devErr, sdk.ErrNotFound) || (devErr == nil && key == "") {
+		d.SetId("")
+		return nil
+	}
+	if devErr != nil {
+		return diag.FromErr(devErr)
+	}
+
+	result := multierror.Append(
+		d.Set("project_id", d.Id()),
+		d.Set("public_key", key),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceProjectGitDeployKeyDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "git deploy key has not been deleted",
+			Detail:   "Git deploy keys cannot be deleted using the Looker API. The deploy key of project " + d.Id() + " has been removed from the terraform state, but still exists in Looker.",
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package looker
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Projects cannot be deleted using the Looker API, so there is no sweeper for this resource.
+func TestAccLookerProject(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_project")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "looker_project" "test_acc" {
+					name           = "test_acc_project"
+					git_remote_url = "git@github.com:example/test_acc_project.git"
+				}
+
+				resource "looker_project_git_deploy_key" "test_acc" {
+					project_id = looker_project.test_acc.id
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project.test_acc", "id", "test_acc_project"),
+					resource.TestCheckResourceAttr("looker_project.test_acc", "git_service_name", "github"),
+					resource.TestCheckResourceAttr("looker_project.test_acc", "pull_request_mode", "off"),
+					resource.TestCheckResourceAttr("looker_project.test_acc", "uses_git", "true"),
+					resource.TestCheckResourceAttrSet("looker_project_git_deploy_key.test_acc", "public_key"),
+				),
+			},
+			{
+				Config: `
+				resource "looker_project" "test_acc" {
+					name                = "test_acc_project"
+					git_remote_url      = "git@github.com:example/test_acc_project.git"
+					pull_request_mode   = "required"
+					validation_required = true
+				}
+
+				resource "looker_project_git_deploy_key" "test_acc" {
+					project_id = looker_project.test_acc.id
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project.test_acc", "pull_request_mode", "required"),
+					resource.TestCheckResourceAttr("looker_project.test_acc", "validation_required", "true"),
+				),
+			},
+		},
+	})
+}
@@ -44,8 +44,11 @@ func resourceRole() *schema.Resource {
 	}
 }
 
+// roleAttrs are the attributes of looker_role which are sent in the body of a role.
+var roleAttrs = []string{"name", "permission_set_id", "model_set_id"}
+
 func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	role, roleErr := api.CreateRole(
 		sdk.WriteRole{
@@ -55,7 +58,7 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, c interface
 		}, nil,
 	)
 	if roleErr != nil {
-		return diag.FromErr(roleErr)
+		return apiDiags(roleErr, "failed to create role", roleAttrs...)
 	}
 
 	if role.Id == nil {
@@ -67,7 +70,7 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, c interface
 }
 
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	role, roleErr := api.Role(d.Id(), nil)
 	if errors.Is(roleErr, sdk.ErrNotFound) {
@@ -88,7 +91,7 @@ func resourceRoleRead(ctx context.Context, d *schema.ResourceData, c interface{}
 }
 
 func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, updateErr := api.UpdateRole(d.Id(),
 		sdk.WriteRole{
@@ -98,14 +101,14 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, c interface
 		}, nil,
 	)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return apiDiags(updateErr, "failed to update role", roleAttrs...)
 	}
 
 	return resourceRoleRead(ctx, d, c)
 }
 
 func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, delErr := api.DeleteRole(d.Id(), nil)
 	if !errors.Is(delErr, sdk.ErrNotFound) {
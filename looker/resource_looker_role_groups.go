@@ -17,7 +17,7 @@ import (
 
 func resourceRoleGroups() *schema.Resource {
 	return &schema.Resource{
-		Description: "This resource binds a set of groups to a Looker role. There can only be one `looker_role_groups` resource per role. This is an additive and non-authorative resource that grants groups to a role in addition to current groups configured in Looker.",
+		Description: "This resource binds a set of groups to a Looker role. There can only be one `looker_role_groups` resource per role. By default this is an additive and non-authorative resource that grants groups to a role in addition to current groups configured in Looker. If `authoritative` is set, any group on the role that is not defined in this resource is removed.",
 
 		CreateContext: resourceRoleGroupsCreate,
 		ReadContext:   resourceRoleGroupsRead,
@@ -42,12 +42,24 @@ func resourceRoleGroups() *schema.Resource {
 				Required:    true,
 				Description: "An unordered list of group ids to be granted the role",
 			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, any group on the role that is not defined in this resource is removed. If false, groups are granted the role in addition to the groups already configured in Looker",
+			},
 		},
 	}
 }
 
 func resourceRoleGroupsCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	// the groups of the role are read before they are set, so changes to the groups of the same role are serialised
+	lockKey := roleGroupsLockKey(d.Get("role_id").(string))
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
 
 	roleID := d.Get("role_id").(string)
 
@@ -67,6 +79,21 @@ func resourceRoleGroupsCreate(ctx context.Context, d *schema.ResourceData, c int
 		return diag.FromErr(gErr)
 	}
 
+	if d.Get("authoritative").(bool) {
+		// terraform owns all groups on the role, so the groups in looker are replaced
+		_, setErr := api.SetRoleGroups(roleID, groupIDs, nil)
+		if errors.Is(setErr, sdk.ErrNotFound) {
+			return diag.Errorf("role with id %s cannot be found", roleID)
+		}
+		if setErr != nil {
+			return diag.FromErr(setErr)
+		}
+
+		d.SetId(fmt.Sprintf("%s_%s", roleID, strings.Join(groupIDs, "_")))
+
+		return resourceRoleGroupsRead(ctx, d, c)
+	}
+
 	diff := slice.Diff(lookerGroupIDs, groupIDs)
 	if len(diff) <= 0 {
 		return resourceRoleGroupsRead(ctx, d, c)
@@ -90,7 +117,7 @@ func resourceRoleGroupsCreate(ctx context.Context, d *schema.ResourceData, c int
 }
 
 func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	// read groups that are set on the role
 	roleID := d.Get("role_id").(string)
@@ -99,6 +126,16 @@ func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, c inter
 		return diag.FromErr(groupsErr)
 	}
 
+	// all groups are set to the state of an authoritative resource, so that groups added outside of terraform are reported as drift
+	if d.Get("authoritative").(bool) {
+		result := multierror.Append(
+			d.Set("role_id", roleID),
+			d.Set("group_ids", lookerGroupIDs),
+		)
+
+		return diag.FromErr(result.ErrorOrNil())
+	}
+
 	// read groups to be set by this resource
 	gIDs, ok := d.Get("group_ids").(*schema.Set)
 	if !ok {
@@ -120,7 +157,13 @@ func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, c inter
 }
 
 func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	// the groups of the role are read before they are set, so changes to the groups of the same role are serialised
+	lockKey := roleGroupsLockKey(d.Get("role_id").(string))
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
 
 	// this method only handles group_id changes as resource is replaced if role_id changes
 	// get old and new groups set on this resource
@@ -149,9 +192,13 @@ func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, c int
 		return diag.FromErr(groupsErr)
 	}
 
-	// diff between what was has changed in the state and what is in looker
-	diff := slice.LeftDiff(oldIDs, lookerGroupIDs)
-	_, setErr := api.SetRoleGroups(roleID, append(diff, newIDs...), nil)
+	// diff between what was has changed in the state and what is in looker, which is not kept if the resource is authoritative
+	toSet := newIDs
+	if !d.Get("authoritative").(bool) {
+		toSet = append(slice.LeftDiff(oldIDs, lookerGroupIDs), newIDs...)
+	}
+
+	_, setErr := api.SetRoleGroups(roleID, toSet, nil)
 	if errors.Is(setErr, sdk.ErrNotFound) {
 		return diag.Errorf("role with id %s cannot be found", roleID)
 	}
@@ -165,7 +212,13 @@ func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, c int
 }
 
 func resourceRoleGroupsDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	// the groups of the role are read before they are set, so changes to the groups of the same role are serialised
+	lockKey := roleGroupsLockKey(d.Get("role_id").(string))
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
 
 	gIDs, ok := d.Get("group_ids").(*schema.Set)
 	if !ok {
@@ -182,7 +235,13 @@ func resourceRoleGroupsDelete(ctx context.Context, d *schema.ResourceData, c int
 		return diag.FromErr(groupsErr)
 	}
 
-	_, setErr := api.SetRoleGroups(roleID, slice.Diff(lookerGroupIDs, groupIDs), nil)
+	// an authoritative resource owns all groups on the role, so all groups are removed
+	toSet := slice.Diff(lookerGroupIDs, groupIDs)
+	if d.Get("authoritative").(bool) {
+		toSet = []string{}
+	}
+
+	_, setErr := api.SetRoleGroups(roleID, toSet, nil)
 	if !errors.Is(setErr, sdk.ErrNotFound) {
 		return diag.FromErr(setErr)
 	}
@@ -194,7 +253,7 @@ func resourceRoleGroupImport(ctx context.Context, d *schema.ResourceData, m inte
 	// id is delimited using `_`, eg. <role_id>_<group_ids>
 	s := strings.Split(d.Id(), "_")
 	if len(s) < 2 {
-		diag.Errorf("invalid id, should be of the form <role_id>_<group_ids>")
+		return nil, fmt.Errorf("invalid id %q, should be of the form <role_id>_<group_ids>", d.Id())
 	}
 
 	resErr := multierror.Append(
@@ -208,6 +267,11 @@ func resourceRoleGroupImport(ctx context.Context, d *schema.ResourceData, m inte
 	return []*schema.ResourceData{d}, nil
 }
 
+// roleGroupsLockKey returns the key of the lock that guards the groups of the role.
+func roleGroupsLockKey(roleID string) string {
+	return "role_groups/" + roleID
+}
+
 func getGroupsOnRole(api *sdk.LookerSDK, roleID string) ([]string, error) {
 	g, gErr := api.RoleGroups(roleID, "id", nil)
 	if errors.Is(gErr, sdk.ErrNotFound) {
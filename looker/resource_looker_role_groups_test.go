@@ -6,7 +6,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
@@ -162,6 +161,94 @@ func TestAccLookerRoleGroups(t *testing.T) {
 	})
 }
 
+func TestAccLookerRoleGroupsAuthoritative(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_role_groups_authoritative")
+	defer stop() //nolint:errcheck
+
+	config := `
+	resource "looker_permission_set" "test_acc" {
+		name        = "test-acc-permission-set-authoritative"
+		permissions = ["access_data", "see_lookml", "see_lookml_dashboards"]
+	}
+
+	resource "looker_model_set" "test_acc" {
+		name   = "test-acc-model-set-authoritative"
+		models = ["test_dataset_1", "test_dataset_2", "test_both_datasets"]
+	}
+
+	resource "looker_role" "test_acc" {
+		name              = "test-acc-role-authoritative"
+		model_set_id      = looker_model_set.test_acc.id
+		permission_set_id = looker_permission_set.test_acc.id
+	}
+
+	resource "looker_group" "test_acc_1" {
+		name = "test-acc-group-authoritative-1"
+	}
+
+	resource "looker_group" "test_acc_2" {
+		name = "test-acc-group-authoritative-2"
+	}
+
+	resource "looker_group" "test_acc_3" {
+		name = "test-acc-group-authoritative-3"
+	}
+
+	resource "looker_role_groups" "test_acc" {
+		role_id       = looker_role.test_acc.id
+		group_ids     = [looker_group.test_acc_1.id, looker_group.test_acc_2.id]
+		authoritative = true
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_role_groups.test_acc", "group_ids.#", "2"),
+					testAccRoleGroups("looker_role_groups.test_acc", []string{"looker_group.test_acc_1", "looker_group.test_acc_2"}),
+				),
+			},
+			{
+				// grant the role to all test groups outside of terraform, the group not in the resource should be removed on apply
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*lookerClient)
+
+					roles, err := client.SearchRoles(sdk.RequestSearchRoles{
+						Name: conv.P("test-acc-role-authoritative"),
+					}, nil)
+					if err != nil || len(roles) != 1 {
+						t.Fatalf("failed to find role test-acc-role-authoritative: %v", err)
+					}
+
+					groups, err := client.SearchGroups(sdk.RequestSearchGroups{
+						Name: conv.P("test-acc-group-authoritative-%"),
+					}, nil)
+					if err != nil {
+						t.Fatalf("failed to search groups: %v", err)
+					}
+
+					groupIDs := make([]string, len(groups))
+					for i, g := range groups {
+						groupIDs[i] = *g.Id
+					}
+
+					if _, err := client.SetRoleGroups(*roles[0].Id, groupIDs, nil); err != nil {
+						t.Fatalf("failed to set groups on role: %v", err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_role_groups.test_acc", "group_ids.#", "2"),
+					testAccRoleGroups("looker_role_groups.test_acc", []string{"looker_group.test_acc_1", "looker_group.test_acc_2"}),
+				),
+			},
+		},
+	})
+}
+
 func testAccRoleGroups(roleGroupResource string, groupResources []string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		roleGroupsRes, ok := s.RootModule().Resources[roleGroupResource]
@@ -185,12 +272,12 @@ func testAccRoleGroups(roleGroupResource string, groupResources []string) resour
 			expectedGroupIds = append(expectedGroupIds, groupRes.Primary.ID)
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		// role group binding resource id is NOT the id of the role resource
 		roleId := strings.Split(roleGroupsRes.Primary.ID, "_")
 		if len(roleId) < 2 {
-			diag.Errorf("invalid id, should be of the form <role_id>_<group_ids>")
+			return fmt.Errorf("invalid id %q, should be of the form <role_id>_<group_ids>", roleGroupsRes.Primary.ID)
 		}
 
 		roleGroups, err := client.RoleGroups(roleId[0], "", nil)
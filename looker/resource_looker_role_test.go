@@ -134,7 +134,7 @@ func testAccRole(roleResource string, expectedModelsSets, expectedPermSets []str
 			return errors.New("role ID is not set")
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		role, err := client.Role(roleRes.Primary.ID, nil)
 		if err != nil {
@@ -2,11 +2,12 @@ package looker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
-	"math/rand"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,6 +16,9 @@ import (
 	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
 )
 
+// samlConfigID is the id of the looker_saml_config resource. There is only one SAML config in a Looker instance.
+const samlConfigID = "saml"
+
 func resourceSamlConfig() *schema.Resource {
 	return &schema.Resource{
 		Description: "This resource updates the SAML config in a Looker instance.",
@@ -24,223 +28,205 @@ func resourceSamlConfig() *schema.Resource {
 		UpdateContext: resourceSamlConfigCreateOrUpdate,
 		DeleteContext: resourceSamlConfigDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSamlConfigImport,
 		},
-
-		Schema: map[string]*schema.Schema{
-			"enabled": {
-				Type:        schema.TypeBool,
-				Required:    true,
-				Description: "Allows roles to be directly assigned to SAML auth'd users.",
-			},
-			"idp_cert": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Identity Provider Certificate (provided by IdP)",
-				Sensitive:   true,
-			},
-			"idp_url": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Identity Provider Url (provided by IdP)",
-			},
-			"idp_issuer": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Identity Provider Issuer (provided by IdP)",
-			},
-			"idp_audience": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Identity Provider Audience (set in IdP config). Optional in Looker. Set this only if you want Looker to validate the audience value returned by the IdP.",
-			},
-			"allowed_clock_drift": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     0,
-				Description: "Count of seconds of clock drift to allow when validating timestamps of assertions.",
-			},
-			"user_attribute_map_email": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of user record attributes used to indicate email address field",
-			},
-			"user_attribute_map_first_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of user record attributes used to indicate first name",
-			},
-			"user_attribute_map_last_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of user record attributes used to indicate last name",
-			},
-			"new_user_migration_types": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Merge first-time saml login to existing user account by email addresses. When a user logs in for the first time via saml this option will connect this user into their existing account by finding the account with a matching email address by testing the given types of credentials for existing users. Otherwise a new user account will be created for the user.",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
-						"email", "ldap", "google",
-					}, false)),
-				},
-			},
-			"default_new_user_role_ids": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Array of ids of roles that will be applied to new users the first time they login via Saml",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-			"default_new_user_group_ids": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Array of ids of groups that will be applied to new users the first time they login via Saml",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-			"auth_requires_role": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Users will not be allowed to login at all unless a role for them is found in Saml if set to true",
-			},
-			"bypass_login_page": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Bypass the login page when user authentication is required. Redirect to IdP immediately instead.",
+		CustomizeDiff: resourceSamlConfigCustomizeDiff,
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				// the attributes of version 0 have the same types, so the current schema is used to decode the state
+				Type:    (&schema.Resource{Schema: samlConfigSchema()}).CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceSamlConfigStateUpgradeV0,
+				Version: 0,
 			},
-			"allow_direct_roles": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Allows roles to be directly assigned to SAML auth'd users.",
-			},
-			"allow_normal_group_membership": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Allow SAML auth'd users to be members of non-reflected Looker groups. If 'false', user will be removed from non-reflected groups on login.",
-			},
-			"alternate_email_login_allowed": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Allow alternate email-based login via '/login/email' for admins and for specified users with the 'login_special_email' permission. This option is useful as a fallback during ldap setup, if ldap config problems occur later, or if you need to support some users who are not in your ldap directory. Looker email/password logins are always disabled for regular users when ldap is enabled.",
-			},
-			"allow_roles_from_normal_groups": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "SAML auth'd users will inherit roles from non-reflected Looker groups.",
-			},
-			"groups_attribute": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Name of user record attributes used to indicate groups. Used when 'groups_finder_type' is set to 'grouped_attribute_values'",
-			},
-			"groups_finder_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "grouped_attribute_values",
+		},
+
+		Schema: samlConfigSchema(),
+	}
+}
+
+func samlConfigSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"enabled": {
+			Type:        schema.TypeBool,
+			Required:    true,
+			Description: "Allows roles to be directly assigned to SAML auth'd users.",
+		},
+		"idp_cert": {
+			Type:             schema.TypeString,
+			Required:         true,
+			Description:      "Identity Provider Certificate (provided by IdP), either PEM encoded or as the base64 encoded DER found in the IdP metadata. Plans fail if the certificate cannot be parsed, or if it is not valid when it is changed or SAML is enabled. A certificate which expires within 30 days, or has expired since it was applied, is reported as a warning",
+			Sensitive:        true,
+			ValidateDiagFunc: validateIdpCert,
+		},
+		"idp_url": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Identity Provider Url (provided by IdP)",
+		},
+		"idp_issuer": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Identity Provider Issuer (provided by IdP)",
+		},
+		"idp_audience": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Identity Provider Audience (set in IdP config). Optional in Looker. Set this only if you want Looker to validate the audience value returned by the IdP.",
+		},
+		"allowed_clock_drift": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+			Description: "Count of seconds of clock drift to allow when validating timestamps of assertions.",
+		},
+		"user_attribute_map_email": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of user record attributes used to indicate email address field",
+		},
+		"user_attribute_map_first_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of user record attributes used to indicate first name",
+		},
+		"user_attribute_map_last_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of user record attributes used to indicate last name",
+		},
+		"new_user_migration_types": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Merge first-time saml login to existing user account by email addresses. When a user logs in for the first time via saml this option will connect this user into their existing account by finding the account with a matching email address by testing the given types of credentials for existing users. Otherwise a new user account will be created for the user.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
-					"grouped_attribute_values",
-					"individual_attributes",
+					"email", "ldap", "google",
 				}, false)),
-				Description: "Identifier for a strategy for how Looker will find groups in the SAML response.",
 			},
-			"groups_with_role_ids": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Array of mappings between Saml Groups and arrays of Looker Role ids",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"id": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "Unique Id",
-						},
-						"looker_group_id": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "Unique Id of group in Looker",
-						},
-						"looker_group_name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Name of group in Looker",
-						},
-						"name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Name of group in Saml",
-						},
-						"role_ids": {
-							Type:        schema.TypeSet,
-							Required:    true,
-							Description: "Looker Role Ids",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-					},
-				},
-			},
-			"groups_member_value": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Value for group attribute used to indicate membership. Used when 'groups_finder_type' is set to 'individual_attributes'",
-			},
-			"set_roles_from_groups": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Set user roles in Looker based on groups from Saml",
-			},
-			"user_attributes_with_ids": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "Array of mappings between Saml User Attributes and arrays of Looker User Attribute ids",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Name of User Attribute in Saml",
-						},
-						"required": {
-							Type:        schema.TypeBool,
-							Required:    true,
-							Description: "Required to be in Saml assertion for login to be allowed to succeed",
-						},
-						"user_attribute_ids": {
-							Type:        schema.TypeSet,
-							Required:    true,
-							Description: "Looker User Attribute Ids",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-					},
-				},
+		},
+		"default_new_user_role_ids": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Array of ids of roles that will be applied to new users the first time they login via Saml",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"id": {
-				Description: "This is set to a random value at create time",
-				Computed:    true,
-				Type:        schema.TypeString,
+		},
+		"default_new_user_group_ids": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Array of ids of groups that will be applied to new users the first time they login via Saml",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
 		},
+		"auth_requires_role": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Users will not be allowed to login at all unless a role for them is found in Saml if set to true",
+		},
+		"bypass_login_page": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Bypass the login page when user authentication is required. Redirect to IdP immediately instead.",
+		},
+		"allow_direct_roles": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allows roles to be directly assigned to SAML auth'd users.",
+		},
+		"allow_normal_group_membership": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allow SAML auth'd users to be members of non-reflected Looker groups. If 'false', user will be removed from non-reflected groups on login.",
+		},
+		"alternate_email_login_allowed": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allow alternate email-based login via '/login/email' for admins and for specified users with the 'login_special_email' permission. This option is useful as a fallback during ldap setup, if ldap config problems occur later, or if you need to support some users who are not in your ldap directory. Looker email/password logins are always disabled for regular users when ldap is enabled.",
+		},
+		"allow_roles_from_normal_groups": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "SAML auth'd users will inherit roles from non-reflected Looker groups.",
+		},
+		"groups_attribute": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Name of user record attributes used to indicate groups. Used when 'groups_finder_type' is set to 'grouped_attribute_values'",
+		},
+		"groups_finder_type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "grouped_attribute_values",
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+				"grouped_attribute_values",
+				"individual_attributes",
+			}, false)),
+			Description: "Identifier for a strategy for how Looker will find groups in the SAML response.",
+		},
+		"groups_with_role_ids": groupsWithRoleIDsSchema("Saml"),
+		"groups_member_value": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: "Value for group attribute used to indicate membership. Used when 'groups_finder_type' is set to 'individual_attributes'",
+		},
+		"set_roles_from_groups": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Set user roles in Looker based on groups from Saml",
+		},
+		"user_attributes_with_ids": userAttributesWithIDsSchema("Saml"),
+		"on_destroy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "disable",
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+				"disable",
+				"restore",
+			}, false)),
+			Description: "What happens to the SAML config when the resource is destroyed. SAML login is always disabled. With `disable`, the rest of the config is left in place. With `restore`, the config in `snapshot` is restored",
+		},
+		"snapshot": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The SAML config of the instance before the resource was created, as JSON. It is restored on destroy when `on_destroy` is `restore`. A config which was imported has no snapshot",
+		},
+		"force": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Allows SAML to be enabled or disabled while `alternate_email_login_allowed` is false, and `alternate_email_login_allowed` to be set to false while SAML is enabled. Without `force`, these changes are refused, as admins would have no email/password login to fall back on if SAML does not work for them. This also applies to destroying the resource, so `force` must be applied before the resource is destroyed",
+		},
+		"id": {
+			Description: "This is always `saml`, as there is only one SAML config in a Looker instance",
+			Computed:    true,
+			Type:        schema.TypeString,
+		},
 	}
+
+	for k, v := range idpCertSchema() {
+		s[k] = v
+	}
+
+	return s
 }
 
 func resourceSamlConfigRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	cfg, err := api.SamlConfig(nil)
 	if errors.Is(err, sdk.ErrNotFound) {
@@ -277,15 +263,35 @@ func resourceSamlConfigRead(ctx context.Context, d *schema.ResourceData, c inter
 		d.Set("user_attributes_with_ids", flattenUserAttributesWithIDs(cfg.UserAttributes)),
 	)
 
-	if cfg.NewUserMigrationTypes != nil {
+	if cfg.NewUserMigrationTypes != nil && *cfg.NewUserMigrationTypes != "" {
 		result = multierror.Append(result, d.Set("new_user_migration_types", strings.Split(*cfg.NewUserMigrationTypes, ",")))
 	}
+	if err := setIdpCert(d, conv.Deref(cfg.IdpCert)); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if result.ErrorOrNil() != nil {
+		return diag.FromErr(result)
+	}
 
-	return diag.FromErr(result.ErrorOrNil())
+	return idpCertWarnings(conv.Deref(cfg.IdpCert), time.Now(), cty.GetAttrPath("idp_cert"))
 }
 
 func resourceSamlConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	// existing mappings are matched by name, so that they are updated rather than replaced
+	current, err := api.SamlConfig(nil)
+	if err != nil && !errors.Is(err, sdk.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+	groupIDs := make(map[string]*string)
+	if current.Groups != nil {
+		for _, g := range *current.Groups {
+			if g.Name != nil {
+				groupIDs[*g.Name] = g.Id
+			}
+		}
+	}
 
 	groupsWithRoleIDs := make([]sdk.SamlGroupWrite, 0)
 	if vs, ok := d.GetOk("groups_with_role_ids"); ok {
@@ -296,21 +302,12 @@ func resourceSamlConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 				return diag.FromErr(err)
 			}
 
-			write := sdk.SamlGroupWrite{
+			groupsWithRoleIDs = append(groupsWithRoleIDs, sdk.SamlGroupWrite{
+				Id:              groupIDs[sgw["name"].(string)],
 				LookerGroupName: conv.P(sgw["looker_group_name"].(string)),
 				Name:            conv.P(sgw["name"].(string)),
 				RoleIds:         conv.P(roleIDs),
-			}
-
-			// check for existing ID to ensure we update existing relationship
-			if _, ok := sgw["id"]; ok && sgw["id"].(string) != "" {
-				write.Id = conv.P(sgw["id"].(string))
-			} else {
-				id := fmt.Sprintf("%d", rand.Int())
-				write.Id = &id
-			}
-
-			groupsWithRoleIDs = append(groupsWithRoleIDs, write)
+			})
 		}
 	}
 
@@ -372,6 +369,17 @@ func resourceSamlConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 		UserAttributesWithIds:      conv.P(userAttributesWithIds),
 	}
 
+	// the config of the instance is saved when the resource is created, so that it can be restored when the resource is destroyed
+	if d.IsNewResource() {
+		snapshot, err := json.Marshal(samlConfigToWrite(current))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("snapshot", string(snapshot)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if _, err := api.UpdateSamlConfig(cfg, nil); err != nil {
 		if errors.Is(err, sdk.ErrNotFound) {
 			d.SetId("")
@@ -380,46 +388,139 @@ func resourceSamlConfigCreateOrUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	d.SetId(fmt.Sprintf("%d", rand.Int()))
+	d.SetId(samlConfigID)
 
 	return resourceSamlConfigRead(ctx, d, c)
 }
 
 func resourceSamlConfigDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
-
-	if _, err := api.UpdateSamlConfig(sdk.WriteSamlConfig{
-		Enabled:                    conv.P(false),
-		IdpCert:                    conv.P(""),
-		IdpUrl:                     conv.P(""),
-		IdpIssuer:                  conv.P(""),
-		IdpAudience:                conv.P(""),
-		AllowedClockDrift:          conv.P(int64(0)),
-		UserAttributeMapEmail:      conv.P(""),
-		UserAttributeMapFirstName:  conv.P(""),
-		UserAttributeMapLastName:   conv.P(""),
-		NewUserMigrationTypes:      conv.P(""),
-		DefaultNewUserGroupIds:     conv.P([]string{}),
-		DefaultNewUserRoleIds:      conv.P([]string{}),
-		AuthRequiresRole:           conv.P(false),
-		BypassLoginPage:            conv.P(false),
-		AllowDirectRoles:           conv.P(false),
-		AllowNormalGroupMembership: conv.P(false),
-		AlternateEmailLoginAllowed: conv.P(false),
-		AllowRolesFromNormalGroups: conv.P(false),
-		GroupsAttribute:            conv.P(""),
-		GroupsFinderType:           conv.P(""),
-		GroupsWithRoleIds:          conv.P([]sdk.SamlGroupWrite{}),
-		GroupsMemberValue:          conv.P(""),
-		SetRolesFromGroups:         conv.P(false),
-		UserAttributesWithIds:      conv.P([]sdk.SamlUserAttributeWrite{}),
-	}, nil); !errors.Is(err, sdk.ErrNotFound) {
+	api := c.(*lookerClient).LookerSDK
+
+	enabled := d.Get("enabled").(bool)
+	alternate := d.Get("alternate_email_login_allowed").(bool)
+	if err := samlLockoutGuard(enabled, false, alternate, alternate, d.Get("force").(bool)); err != nil {
 		return diag.FromErr(err)
 	}
 
+	cfg := sdk.WriteSamlConfig{}
+	if snapshot := d.Get("snapshot").(string); d.Get("on_destroy").(string) == "restore" && snapshot != "" {
+		if err := json.Unmarshal([]byte(snapshot), &cfg); err != nil {
+			return diag.Errorf("failed to restore the snapshot of the SAML config: %s", err)
+		}
+	}
+	// SAML login is disabled whatever the config in the snapshot, so the instance is left with email/password login
+	cfg.Enabled = conv.P(false)
+
+	if _, err := api.UpdateSamlConfig(cfg, nil); err != nil && !errors.Is(err, sdk.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceSamlConfigCustomizeDiff refuses plans which could lock every admin out of the instance or which apply an expired IdP
+// certificate, and plans the attributes which describe the IdP certificate.
+func resourceSamlConfigCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+	if d.HasChange("idp_cert") {
+		for k, v := range flattenIdpCert(d.Get("idp_cert").(string)) {
+			var err error
+			if d.NewValueKnown("idp_cert") {
+				err = d.SetNew(k, v)
+			} else {
+				err = d.SetNewComputed(k)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	oldEnabled, newEnabled := d.GetChange("enabled")
+	oldAlternate, newAlternate := d.GetChange("alternate_email_login_allowed")
+
+	// the validity of the certificate depends on the time of the plan, so an expired certificate only fails plans which change it or
+	// enable SAML, and is otherwise reported as a warning when the config is read
+	if (d.HasChange("idp_cert") && d.NewValueKnown("idp_cert")) || (!oldEnabled.(bool) && newEnabled.(bool)) {
+		if err := idpCertValidityError(d.Get("idp_cert").(string), time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return samlLockoutGuard(oldEnabled.(bool), newEnabled.(bool), oldAlternate.(bool), newAlternate.(bool), d.Get("force").(bool))
+}
+
+// samlLockoutGuard returns an error when SAML is enabled or disabled while admins cannot log in with email/password when SAML is
+// enabled, or when the email/password login of admins is removed while SAML stays enabled. If SAML does not work after it is
+// enabled, or admins only have SAML credentials when it is disabled, every admin would be locked out of the instance.
+func samlLockoutGuard(oldEnabled, newEnabled, oldAlternate, newAlternate, force bool) error {
+	if force {
+		return nil
+	}
+
+	switch {
+	case !oldEnabled && newEnabled && !newAlternate:
+		return errors.New("refusing to enable SAML while alternate_email_login_allowed is false, as admins could be locked out of the instance. " +
+			"Set alternate_email_login_allowed to true, or set force to true to enable SAML anyway")
+	case oldEnabled && !newEnabled && !oldAlternate:
+		// the admins must have been able to log in with email/password while SAML was enabled
+		return errors.New("refusing to disable SAML while alternate_email_login_allowed is false, as admins could be locked out of the instance. " +
+			"Set alternate_email_login_allowed to true, or set force to true to disable SAML anyway")
+	case oldEnabled && newEnabled && oldAlternate && !newAlternate:
+		return errors.New("refusing to set alternate_email_login_allowed to false while SAML is enabled, as admins could be locked out of the instance. " +
+			"Set force to true to remove the email/password login of admins anyway")
+	}
+
 	return nil
 }
 
+// samlConfigToWrite returns the write request which restores the SAML config.
+func samlConfigToWrite(cfg sdk.SamlConfig) sdk.WriteSamlConfig {
+	return sdk.WriteSamlConfig{
+		Enabled:                    cfg.Enabled,
+		IdpCert:                    cfg.IdpCert,
+		IdpUrl:                     cfg.IdpUrl,
+		IdpIssuer:                  cfg.IdpIssuer,
+		IdpAudience:                cfg.IdpAudience,
+		AllowedClockDrift:          cfg.AllowedClockDrift,
+		UserAttributeMapEmail:      cfg.UserAttributeMapEmail,
+		UserAttributeMapFirstName:  cfg.UserAttributeMapFirstName,
+		UserAttributeMapLastName:   cfg.UserAttributeMapLastName,
+		NewUserMigrationTypes:      cfg.NewUserMigrationTypes,
+		AlternateEmailLoginAllowed: cfg.AlternateEmailLoginAllowed,
+		DefaultNewUserRoleIds:      cfg.DefaultNewUserRoleIds,
+		DefaultNewUserGroupIds:     cfg.DefaultNewUserGroupIds,
+		SetRolesFromGroups:         cfg.SetRolesFromGroups,
+		GroupsAttribute:            cfg.GroupsAttribute,
+		GroupsWithRoleIds:          cfg.GroupsWithRoleIds,
+		AuthRequiresRole:           cfg.AuthRequiresRole,
+		UserAttributesWithIds:      cfg.UserAttributesWithIds,
+		GroupsFinderType:           cfg.GroupsFinderType,
+		GroupsMemberValue:          cfg.GroupsMemberValue,
+		BypassLoginPage:            cfg.BypassLoginPage,
+		AllowNormalGroupMembership: cfg.AllowNormalGroupMembership,
+		AllowRolesFromNormalGroups: cfg.AllowRolesFromNormalGroups,
+		AllowDirectRoles:           cfg.AllowDirectRoles,
+	}
+}
+
+// resourceSamlConfigImport imports the SAML config of the instance. There is only one SAML config, so the id passed to import is ignored.
+func resourceSamlConfigImport(ctx context.Context, d *schema.ResourceData, c interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(samlConfigID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceSamlConfigStateUpgradeV0 replaces the random id of version 0 with the id of the singleton SAML config. The ids of the
+// groups_with_role_ids mappings are the ids in Looker, so they are kept.
+func resourceSamlConfigStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	rawState["id"] = samlConfigID
+
+	return rawState, nil
+}
+
 func flattenGroupsWithRoleIDs(sgws *[]sdk.SamlGroupRead) []interface{} {
 	if sgws == nil {
 		return nil
@@ -428,18 +529,24 @@ func flattenGroupsWithRoleIDs(sgws *[]sdk.SamlGroupRead) []interface{} {
 	groupsWithRoleIDs := make([]interface{}, len(*sgws))
 
 	for i, sgw := range *sgws {
-		groupsWithRoleIDs[i] = map[string]interface{}{
-			"id":                sgw.Id,
-			"looker_group_id":   sgw.LookerGroupId,
-			"looker_group_name": sgw.LookerGroupName,
-			"name":              sgw.Name,
-			"role_ids":          flattenRoles(sgw.Roles),
-		}
+		groupsWithRoleIDs[i] = flattenGroupWithRoleIDs(sgw.Id, sgw.LookerGroupId, sgw.LookerGroupName, sgw.Name, sgw.Roles)
 	}
 
 	return groupsWithRoleIDs
 }
 
+// flattenGroupWithRoleIDs flattens a mapping between a group of an identity provider and Looker roles. It is shared by the SSO
+// config resources, as the SDK has a separate type for the mapping of each identity provider.
+func flattenGroupWithRoleIDs(id, lookerGroupID, lookerGroupName, name *string, roles *[]sdk.Role) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                id,
+		"looker_group_id":   lookerGroupID,
+		"looker_group_name": lookerGroupName,
+		"name":              name,
+		"role_ids":          flattenRoles(roles),
+	}
+}
+
 func flattenUserAttributesWithIDs(suaws *[]sdk.SamlUserAttributeRead) []interface{} {
 	if suaws == nil {
 		return nil
@@ -448,16 +555,21 @@ func flattenUserAttributesWithIDs(suaws *[]sdk.SamlUserAttributeRead) []interfac
 	userAttributeWithIDs := make([]interface{}, len(*suaws))
 
 	for i, suaw := range *suaws {
-		userAttributeWithIDs[i] = map[string]interface{}{
-			"name":               suaw.Name,
-			"required":           suaw.Required,
-			"user_attribute_ids": flattenUserAttributes(suaw.UserAttributes),
-		}
+		userAttributeWithIDs[i] = flattenUserAttributeWithIDs(suaw.Name, suaw.Required, suaw.UserAttributes)
 	}
 
 	return userAttributeWithIDs
 }
 
+// flattenUserAttributeWithIDs flattens a mapping between a user attribute of an identity provider and Looker user attributes.
+func flattenUserAttributeWithIDs(name *string, required *bool, userAttributes *[]sdk.UserAttribute) map[string]interface{} {
+	return map[string]interface{}{
+		"name":               name,
+		"required":           required,
+		"user_attribute_ids": flattenUserAttributes(userAttributes),
+	}
+}
+
 func flattenGroups(groups *[]sdk.Group) []string {
 	if groups == nil {
 		return []string{}
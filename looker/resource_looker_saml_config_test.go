@@ -0,0 +1,306 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+// testAccIdpCert is a self-signed certificate for idp.orange.com, which expires in 2126.
+const testAccIdpCert = "MIIBrDCCAVGgAwIBAgIUAv0MggL8wluuzjy5YjDk6RMndDYwCgYIKoZIzj0EAwIwKjEXMBUGA1UEAwwOaWRwLm9yYW5nZS5jb20xDzANBgNVBAoMBk9yYW5nZTAgFw0yNjEwMTcyMjI4MjRaGA8yMTI2MDkyMzIyMjgyNFowKjEXMBUGA1UEAwwOaWRwLm9yYW5nZS5jb20xDzANBgNVBAoMBk9yYW5nZTBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABHsVucvYJzxsy1JMXNHKjVeRSFY+gh0hme9ULdZQ7JiY3ZbM5ymlr0DXiyewHMsCtHfzLCSlCm8s6znUDcnFJ7ajUzBRMB0GA1UdDgQWBBSQn0NDLGNo1+zHabw5NLPrMWlqKzAfBgNVHSMEGDAWgBSQn0NDLGNo1+zHabw5NLPrMWlqKzAPBgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0kAMEYCIQCIipMBPg6d8uqmokrQwakEo9Y9JV/5VowE9XZQSTWzjQIhANYt8zPuOO1pZWjN6XZuowdeBv1j0QzI724G7wfo/CG1"
+
+func testAccSamlConfig(enabled, force bool) string {
+	return fmt.Sprintf(`
+	resource "looker_saml_config" "test_acc" {
+		enabled    = %t
+		idp_cert   = "%s"
+		idp_url    = "https://idp.orange.com/saml/test-acc"
+		idp_issuer = "urn:orange:test-acc"
+
+		user_attribute_map_email      = "email"
+		user_attribute_map_first_name = "first_name"
+		user_attribute_map_last_name  = "last_name"
+
+		alternate_email_login_allowed = false
+		on_destroy                    = "restore"
+		force                         = %t
+	}
+	`, enabled, testAccIdpCert, force)
+}
+
+func TestAccLookerSamlConfig(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_saml_config")
+	defer stop() //nolint:errcheck
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccSamlConfigRestored("https://idp.orange.com/saml/original"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSamlConfig(false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "id", "saml"),
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "enabled", "false"),
+					resource.TestMatchResourceAttr("looker_saml_config.test_acc", "snapshot", regexp.MustCompile(`"idp_url":"https://idp.orange.com/saml/original"`)),
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "idp_cert_fingerprint", "B0:89:1D:A1:B5:67:A0:1F:4A:ED:54:6C:76:0A:69:4C:A3:74:1D:4F:63:F8:47:FC:16:68:DA:A8:2F:8D:6A:F5"),
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "idp_cert_subject", "CN=idp.orange.com,O=Orange"),
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "idp_cert_not_after", "2126-09-23T22:28:24Z"),
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "idp_cert_signature_algorithm", "ECDSA-SHA256"),
+				),
+			},
+			{
+				// enabling SAML without an email/password login for admins to fall back on is refused
+				Config:      testAccSamlConfig(true, false),
+				ExpectError: regexp.MustCompile("refusing to enable SAML while alternate_email_login_allowed is false"),
+			},
+			{
+				Config: testAccSamlConfig(true, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "enabled", "true"),
+					resource.TestCheckResourceAttr("looker_saml_config.test_acc", "force", "true"),
+				),
+			},
+		},
+	})
+}
+
+// testAccSamlConfigRestored checks that SAML is disabled, and that the config from before the test has been restored.
+func testAccSamlConfigRestored(idpURL string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*lookerClient)
+
+		cfg, err := client.SamlConfig(nil)
+		if err != nil {
+			return err
+		}
+
+		if cfg.Enabled == nil || *cfg.Enabled {
+			return errors.New("SAML is still enabled")
+		}
+		if cfg.IdpUrl == nil || *cfg.IdpUrl != idpURL {
+			return fmt.Errorf("expected the idp url to be restored to %s, got %v", idpURL, cfg.IdpUrl)
+		}
+
+		return nil
+	}
+}
+
+func TestSamlLockoutGuard(t *testing.T) {
+	tests := []struct {
+		name                       string
+		oldEnabled, newEnabled     bool
+		oldAlternate, newAlternate bool
+		force                      bool
+		wantErr                    bool
+	}{
+		{name: "enable with email login", newEnabled: true, newAlternate: true},
+		{name: "enable without email login", newEnabled: true, wantErr: true},
+		{name: "enable without email login forced", newEnabled: true, force: true},
+		{name: "disable without email login", oldEnabled: true, wantErr: true},
+		{name: "disable with email login", oldEnabled: true, oldAlternate: true},
+		{name: "stay enabled without email login", oldEnabled: true, newEnabled: true},
+		{name: "stay enabled and remove email login", oldEnabled: true, newEnabled: true, oldAlternate: true, wantErr: true},
+		{name: "stay enabled and remove email login forced", oldEnabled: true, newEnabled: true, oldAlternate: true, force: true},
+		{name: "stay disabled and remove email login", oldAlternate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := samlLockoutGuard(tt.oldEnabled, tt.newEnabled, tt.oldAlternate, tt.newAlternate, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSamlConfigCustomizeDiffIdpCertExpiry(t *testing.T) {
+	now := time.Now()
+	expired := newIdpCert(t, now.AddDate(-2, 0, 0), now.AddDate(0, 0, -1))
+	renewed := newIdpCert(t, now.AddDate(0, 0, -1), now.AddDate(1, 0, 0))
+
+	tests := []struct {
+		name       string
+		oldEnabled string
+		newEnabled bool
+		newCert    string
+		wantErr    bool
+	}{
+		{name: "expired certificate in the state is not an error", oldEnabled: "true", newEnabled: true, newCert: expired},
+		{name: "certificate is replaced with a valid one", oldEnabled: "true", newEnabled: true, newCert: renewed},
+		{name: "certificate is replaced with an expired one", oldEnabled: "true", newEnabled: true, newCert: newIdpCert(t, now.AddDate(-2, 0, 0), now.AddDate(0, 0, -2)), wantErr: true},
+		{name: "SAML is enabled with an expired certificate", oldEnabled: "false", newEnabled: true, newCert: expired, wantErr: true},
+		{name: "SAML stays disabled with an expired certificate", oldEnabled: "false", newEnabled: false, newCert: expired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: samlConfigID,
+				Attributes: map[string]string{
+					"id":                            samlConfigID,
+					"enabled":                       tt.oldEnabled,
+					"idp_cert":                      expired,
+					"idp_url":                       "https://idp.orange.com/sso",
+					"idp_issuer":                    "urn:idp.orange.com",
+					"user_attribute_map_email":      "email",
+					"user_attribute_map_first_name": "first_name",
+					"user_attribute_map_last_name":  "last_name",
+					"alternate_email_login_allowed": "true",
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"enabled":                       tt.newEnabled,
+				"idp_cert":                      tt.newCert,
+				"idp_url":                       "https://idp.orange.com/sso",
+				"idp_issuer":                    "urn:idp.orange.com",
+				"user_attribute_map_email":      "email",
+				"user_attribute_map_first_name": "first_name",
+				"user_attribute_map_last_name":  "last_name",
+				"alternate_email_login_allowed": true,
+			})
+
+			_, err := resourceSamlConfig().Diff(context.Background(), state, config, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %t, got %v", tt.wantErr, err)
+			}
+			if err != nil && !regexp.MustCompile("IdP certificate has expired").MatchString(err.Error()) {
+				t.Errorf("expected the certificate to be reported as expired, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSamlConfigCreateMatchesGroupsByName(t *testing.T) {
+	var written sdk.WriteSamlConfig
+	c := newFakeLookerServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			// the instance already maps the Looker Viewers group
+			json.NewEncoder(w).Encode(sdk.SamlConfig{ //nolint:errcheck
+				Groups: &[]sdk.SamlGroupRead{{
+					Id:              conv.P("7"),
+					Name:            conv.P("Looker Viewers"),
+					LookerGroupName: conv.P("Viewers"),
+				}},
+			})
+		case http.MethodPatch:
+			if err := json.NewDecoder(r.Body).Decode(&written); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, "{}")
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+
+	d := schema.TestResourceDataRaw(t, resourceSamlConfig().Schema, map[string]interface{}{
+		"enabled":                       true,
+		"idp_cert":                      "mycert",
+		"idp_url":                       "https://mydomain.com/samlp/metadata/123456",
+		"idp_issuer":                    "urn:mydomain.com",
+		"user_attribute_map_email":      "email",
+		"user_attribute_map_first_name": "first_name",
+		"user_attribute_map_last_name":  "last_name",
+		"groups_with_role_ids": []interface{}{
+			map[string]interface{}{
+				"name":              "Looker Viewers",
+				"looker_group_name": "Viewers",
+				"role_ids":          []interface{}{"1"},
+			},
+			map[string]interface{}{
+				"name":              "Looker Admins",
+				"looker_group_name": "Admins",
+				"role_ids":          []interface{}{"2"},
+			},
+		},
+	})
+	if diags := resourceSamlConfigCreateOrUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("failed to create saml config: %v", diags)
+	}
+
+	if d.Id() != samlConfigID {
+		t.Errorf("expected id %q, got %q", samlConfigID, d.Id())
+	}
+	if written.GroupsWithRoleIds == nil || len(*written.GroupsWithRoleIds) != 2 {
+		t.Fatalf("expected 2 groups to be written, got %v", written.GroupsWithRoleIds)
+	}
+	for _, g := range *written.GroupsWithRoleIds {
+		switch *g.Name {
+		case "Looker Viewers":
+			if g.Id == nil || *g.Id != "7" {
+				t.Errorf("expected the existing mapping of %q to keep id 7, got %v", *g.Name, g.Id)
+			}
+		case "Looker Admins":
+			if g.Id != nil {
+				t.Errorf("expected the new mapping of %q to have no id, got %q", *g.Name, *g.Id)
+			}
+		default:
+			t.Errorf("unexpected group %q", *g.Name)
+		}
+	}
+}
+
+func TestSamlConfigGroupsWithRoleIDsHash(t *testing.T) {
+	configured := map[string]interface{}{
+		"name":              "Looker Viewers",
+		"looker_group_name": "Viewers",
+		"role_ids":          schema.NewSet(schema.HashString, []interface{}{"1"}),
+	}
+	read := map[string]interface{}{
+		"id":                "7",
+		"looker_group_id":   "3",
+		"name":              "Looker Viewers",
+		"looker_group_name": "Viewers",
+		"role_ids":          schema.NewSet(schema.HashString, []interface{}{"1"}),
+	}
+
+	// the computed ids of a mapping must not change which element of the set it is
+	if hashGroupWithRoleIDs(configured) != hashGroupWithRoleIDs(read) {
+		t.Error("expected a configured mapping and the mapping read from looker to have the same hash")
+	}
+}
+
+func TestSamlConfigStateUpgradeV0(t *testing.T) {
+	groups := []interface{}{
+		map[string]interface{}{
+			"id":                "7",
+			"looker_group_id":   "3",
+			"looker_group_name": "Viewers",
+			"name":              "Looker Viewers",
+			"role_ids":          []interface{}{"1"},
+		},
+	}
+	v0 := map[string]interface{}{
+		"id":                   "5577006791947779410",
+		"enabled":              true,
+		"groups_with_role_ids": groups,
+	}
+
+	v1, err := resourceSamlConfigStateUpgradeV0(context.Background(), v0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v1["id"] != samlConfigID {
+		t.Errorf("expected id %q, got %v", samlConfigID, v1["id"])
+	}
+	if v1["groups_with_role_ids"].([]interface{})[0].(map[string]interface{})["id"] != "7" {
+		t.Errorf("expected the ids of the groups to be kept, got %v", v1["groups_with_role_ids"])
+	}
+}
@@ -0,0 +1,448 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+)
+
+var (
+	scheduledPlanAttrs = []string{"name", "dashboard_id", "look_id", "lookml_dashboard_id", "crontab", "datagroup", "timezone"}
+
+	scheduledPlanSources  = []string{"dashboard_id", "look_id", "lookml_dashboard_id"}
+	scheduledPlanTriggers = []string{"crontab", "datagroup"}
+
+	scheduledPlanDestinationTypes = []string{"email", "webhook", "s3", "sftp"}
+
+	// scheduledPlanFormats are the formats of the data which is delivered, of which the pdf, png and zip formats are the only ones
+	// supported by dashboards.
+	scheduledPlanFormats = []string{
+		"txt", "csv", "inline_json", "json", "json_label", "json_detail", "json_detail_lite_stream", "xlsx", "html",
+		"wysiwyg_pdf", "assembled_pdf", "wysiwyg_png", "csv_zip",
+	}
+)
+
+func resourceScheduledPlan() *schema.Resource {
+	return &schema.Resource{
+		Description: "This resource creates a scheduled plan in a Looker instance, which delivers the data of a dashboard, Look or LookML dashboard to its destinations on a schedule or when a datagroup is triggered. " +
+			"The secret parameters of destinations are write-only, as Looker does not return them, so changes to them in Looker are not detected.",
+
+		CreateContext: resourceScheduledPlanCreate,
+		ReadContext:   resourceScheduledPlanRead,
+		UpdateContext: resourceScheduledPlanUpdate,
+		DeleteContext: resourceScheduledPlanDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The name of the scheduled plan",
+			},
+			"dashboard_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: scheduledPlanSources,
+				Description:  "The id of the dashboard to deliver",
+			},
+			"look_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: scheduledPlanSources,
+				Description:  "The id of the Look to deliver",
+			},
+			"lookml_dashboard_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: scheduledPlanSources,
+				Description:  "The id of the LookML dashboard to deliver, eg. `thelook::orders`",
+			},
+			"crontab": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: scheduledPlanTriggers,
+				Description:  "The schedule of the deliveries as a crontab expression, eg. `0 7 * * 1-5`",
+			},
+			"datagroup": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: scheduledPlanTriggers,
+				Description:  "The name of the datagroup which triggers the deliveries",
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The timezone of the crontab and of the delivered data, eg. `Europe/London`. The timezone of the Looker instance is used when it is not set",
+			},
+			"filters": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The filters applied to the delivered data, as a map of the names of dashboard filters, or of the fields of a Look, to Looker filter expressions",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the deliveries of the scheduled plan are enabled",
+			},
+			"run_once_on_apply": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to deliver the data to all destinations once when the scheduled plan is created or updated, eg. to test the deliveries",
+			},
+			"destination": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The destinations of the deliveries",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(scheduledPlanDestinationTypes, false),
+							Description:  fmt.Sprintf("The type of the destination, one of `%s`", strings.Join(scheduledPlanDestinationTypes, "`, `")),
+						},
+						"format": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(scheduledPlanFormats, false),
+							Description:  fmt.Sprintf("The format of the delivered data, one of `%s`. Dashboards can only be delivered as `wysiwyg_pdf`, `assembled_pdf`, `wysiwyg_png` or `csv_zip`", strings.Join(scheduledPlanFormats, "`, `")),
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The address of the destination, which is an email address, the url of a webhook, or an `s3://` or `sftp://` url",
+						},
+						"parameters": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The parameters of the destination, eg. the `region` and `access_key_id` of an S3 bucket",
+						},
+						"secret_parameters": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Sensitive:   true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The secret parameters of the destination, eg. the `secret_access_key` of an S3 bucket or the `password` of an SFTP server. These are write-only",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The message included in the deliveries to an email destination",
+						},
+						"apply_formatting": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the model-specified formatting is applied to the delivered data",
+						},
+						"apply_vis": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the visualization options are applied to the delivered data",
+						},
+					},
+				},
+			},
+			"run_as_user_id": runAsSchema(),
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the user who owns the scheduled plan",
+			},
+		},
+	}
+}
+
+func resourceScheduledPlanCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	body, expandErr := expandScheduledPlan(d)
+	if expandErr != nil {
+		return diag.FromErr(expandErr)
+	}
+
+	plan, createErr := api.CreateScheduledPlan(body, nil)
+	if createErr != nil {
+		return apiDiags(createErr, "failed to create scheduled plan", scheduledPlanAttrs...)
+	}
+
+	if plan.Id == nil {
+		return diag.Errorf("scheduled plan has missing id")
+	}
+	d.SetId(*plan.Id)
+
+	if diags := runScheduledPlanOnApply(api, d); diags.HasError() {
+		return diags
+	}
+
+	return resourceScheduledPlanRead(ctx, d, c)
+}
+
+func resourceScheduledPlanRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	plan, planErr := api.ScheduledPlan(d.Id(), "", nil)
+	if errors.Is(planErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if planErr != nil {
+		return apiDiags(planErr, "failed to read scheduled plan")
+	}
+
+	filters, filtersErr := flattenScheduledPlanFilters(conv.Deref(plan.FiltersString))
+	if filtersErr != nil {
+		return diag.FromErr(filtersErr)
+	}
+
+	destinations, destinationsErr := flattenScheduledPlanDestinations(plan.ScheduledPlanDestination, d.Get("destination").([]interface{}))
+	if destinationsErr != nil {
+		return diag.FromErr(destinationsErr)
+	}
+
+	result := multierror.Append(
+		d.Set("name", plan.Name),
+		d.Set("dashboard_id", plan.DashboardId),
+		d.Set("look_id", plan.LookId),
+		d.Set("lookml_dashboard_id", plan.LookmlDashboardId),
+		d.Set("crontab", plan.Crontab),
+		d.Set("datagroup", plan.Datagroup),
+		d.Set("timezone", plan.Timezone),
+		d.Set("filters", filters),
+		d.Set("enabled", plan.Enabled),
+		d.Set("destination", destinations),
+		d.Set("user_id", plan.UserId),
+	)
+
+	return diag.FromErr(result.ErrorOrNil())
+}
+
+func resourceScheduledPlanUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	body, expandErr := expandScheduledPlan(d)
+	if expandErr != nil {
+		return diag.FromErr(expandErr)
+	}
+
+	// fields which are removed from the config are cleared, as they are omitted from the body of the request when they are empty
+	if d.HasChange("crontab") {
+		body.Crontab = conv.P(d.Get("crontab").(string))
+	}
+	if d.HasChange("datagroup") {
+		body.Datagroup = conv.P(d.Get("datagroup").(string))
+	}
+	if d.HasChange("timezone") {
+		body.Timezone = conv.P(d.Get("timezone").(string))
+	}
+	if d.HasChange("filters") {
+		body.FiltersString = conv.P(conv.Deref(body.FiltersString))
+	}
+
+	if _, updateErr := api.UpdateScheduledPlan(d.Id(), body, nil); updateErr != nil {
+		return apiDiags(updateErr, "failed to update scheduled plan", scheduledPlanAttrs...)
+	}
+
+	if diags := runScheduledPlanOnApply(api, d); diags.HasError() {
+		return diags
+	}
+
+	return resourceScheduledPlanRead(ctx, d, c)
+}
+
+func resourceScheduledPlanDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
+	api := resourceAPI(d, c)
+
+	_, delErr := api.DeleteScheduledPlan(d.Id(), nil)
+	if !errors.Is(delErr, sdk.ErrNotFound) {
+		return apiDiags(delErr, "failed to delete scheduled plan")
+	}
+
+	return nil
+}
+
+// runScheduledPlanOnApply delivers the data of the scheduled plan to its destinations if run_once_on_apply is set.
+func runScheduledPlanOnApply(api *sdk.LookerSDK, d *schema.ResourceData) diag.Diagnostics {
+	if !d.Get("run_once_on_apply").(bool) {
+		return nil
+	}
+
+	if _, runErr := api.ScheduledPlanRunOnceById(d.Id(), sdk.WriteScheduledPlan{}, nil); runErr != nil {
+		return apiDiags(runErr, "failed to run scheduled plan "+d.Id())
+	}
+
+	return nil
+}
+
+// expandScheduledPlan maps the attributes of the resource to the body of a scheduled plan in the Looker API.
+func expandScheduledPlan(d *schema.ResourceData) (sdk.WriteScheduledPlan, error) {
+	destinations, err := expandScheduledPlanDestinations(d.Get("destination").([]interface{}))
+	if err != nil {
+		return sdk.WriteScheduledPlan{}, err
+	}
+
+	return sdk.WriteScheduledPlan{
+		Name:                     conv.P(d.Get("name").(string)),
+		DashboardId:              conv.PString(d.Get("dashboard_id").(string)),
+		LookId:                   conv.PString(d.Get("look_id").(string)),
+		LookmlDashboardId:        conv.PString(d.Get("lookml_dashboard_id").(string)),
+		Crontab:                  conv.PString(d.Get("crontab").(string)),
+		Datagroup:                conv.PString(d.Get("datagroup").(string)),
+		Timezone:                 conv.PString(d.Get("timezone").(string)),
+		FiltersString:            conv.PString(expandScheduledPlanFilters(d.Get("filters").(map[string]interface{}))),
+		Enabled:                  conv.P(d.Get("enabled").(bool)),
+		ScheduledPlanDestination: conv.P(destinations),
+	}, nil
+}
+
+func expandScheduledPlanDestinations(vs []interface{}) ([]sdk.ScheduledPlanDestination, error) {
+	destinations := make([]sdk.ScheduledPlanDestination, len(vs))
+	for i, v := range vs {
+		dest := v.(map[string]interface{})
+
+		parameters, err := encodeScheduledPlanParameters(dest["parameters"].(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters of destination %d: %w", i, err)
+		}
+		secretParameters, err := encodeScheduledPlanParameters(dest["secret_parameters"].(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret parameters of destination %d: %w", i, err)
+		}
+
+		destinations[i] = sdk.ScheduledPlanDestination{
+			Type:             conv.P(dest["type"].(string)),
+			Format:           conv.P(dest["format"].(string)),
+			Address:          conv.P(dest["address"].(string)),
+			Parameters:       conv.PString(parameters),
+			SecretParameters: conv.PString(secretParameters),
+			Message:          conv.PString(dest["message"].(string)),
+			ApplyFormatting:  conv.P(dest["apply_formatting"].(bool)),
+			ApplyVis:         conv.P(dest["apply_vis"].(bool)),
+		}
+	}
+
+	return destinations, nil
+}
+
+// flattenScheduledPlanDestinations takes the destinations of a scheduled plan and maps them to the destination blocks of the resource.
+// Looker does not return the secret parameters of destinations, so they are kept from the destination at the same index in state.
+// This function returns a []interface{} because the terraform aggregate type schema.TypeList expects a slice of attributes.
+func flattenScheduledPlanDestinations(destinations *[]sdk.ScheduledPlanDestination, state []interface{}) ([]interface{}, error) {
+	if destinations == nil {
+		return nil, nil
+	}
+
+	flattened := make([]interface{}, len(*destinations))
+	for i, dest := range *destinations {
+		parameters, err := decodeScheduledPlanParameters(conv.Deref(dest.Parameters))
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters of destination %s: %w", conv.Deref(dest.Id), err)
+		}
+
+		var secretParameters interface{}
+		if i < len(state) && state[i] != nil {
+			secretParameters = state[i].(map[string]interface{})["secret_parameters"]
+		}
+
+		flattened[i] = map[string]interface{}{
+			"type":              conv.Deref(dest.Type),
+			"format":            conv.Deref(dest.Format),
+			"address":           conv.Deref(dest.Address),
+			"parameters":        parameters,
+			"secret_parameters": secretParameters,
+			"message":           conv.Deref(dest.Message),
+			"apply_formatting":  conv.Deref(dest.ApplyFormatting),
+			"apply_vis":         conv.Deref(dest.ApplyVis),
+		}
+	}
+
+	return flattened, nil
+}
+
+// encodeScheduledPlanParameters returns the parameters of a destination as the JSON object expected by Looker, or an empty string if
+// there are no parameters.
+func encodeScheduledPlanParameters(parameters map[string]interface{}) (string, error) {
+	if len(parameters) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(parameters)
+	return string(b), err
+}
+
+func decodeScheduledPlanParameters(s string) (map[string]interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var parameters map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &parameters); err != nil {
+		return nil, err
+	}
+
+	// the attribute is a map of strings, so other values are set as their JSON encoding
+	for k, v := range parameters {
+		if _, ok := v.(string); ok {
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		parameters[k] = string(b)
+	}
+
+	return parameters, nil
+}
+
+// expandScheduledPlanFilters returns the filters as the query string that Looker stores the filters of a scheduled plan as, eg.
+// `?Status=complete`, or an empty string if there are no filters.
+func expandScheduledPlanFilters(filters map[string]interface{}) string {
+	if len(filters) == 0 {
+		return ""
+	}
+
+	values := make(url.Values, len(filters))
+	for k, v := range filters {
+		values.Set(k, v.(string))
+	}
+
+	return "?" + values.Encode()
+}
+
+func flattenScheduledPlanFilters(s string) (map[string]interface{}, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(s, "?"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid filters %q: %w", s, err)
+	}
+
+	filters := make(map[string]interface{}, len(values))
+	for k := range values {
+		filters[k] = values.Get(k)
+	}
+
+	return filters, nil
+}
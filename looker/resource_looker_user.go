@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 
 	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
@@ -14,14 +15,14 @@ import (
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Description: "This resource creates a user in a Looker instance.",
+		Description: "This resource creates a user in a Looker instance. By default, the user is sent a password reset email when they are created, and deleted when the resource is destroyed.",
 
 		CreateContext: resourceUserCreate,
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceUserImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -40,21 +41,81 @@ func resourceUser() *schema.Resource {
 				Required:    true,
 				Description: "The last name of the user",
 			},
+			"is_disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the user is disabled. Disabled users cannot log in, but keep the content they own",
+			},
+			"locale": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The locale of the user, eg. `en` or `fr_FR`. Defaults to the locale of the instance",
+			},
+			"home_folder_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The id of the folder the user sees when they browse content",
+			},
+			"models_dir_validated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the development mode directory of the user has been validated",
+			},
+			"send_password_reset": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to send a password reset email to the user when the user is created or their email changes. Set this to false for users who log in with SSO, eg. SAML",
+			},
+			"deletion_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "delete",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"delete", "disable"}, false)),
+				Description:      "What happens to the user when the resource is destroyed. With `delete`, the user and the content they own are deleted. With `disable`, the user is disabled, which keeps the content they own",
+			},
+			"credentials_email": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has email/password credentials",
+			},
+			"credentials_saml": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has SAML credentials, ie. the user has logged in with SAML",
+			},
 		},
 	}
 }
 
+// userAttrs are the attributes of looker_user which are sent in the body of a user, and can be reported by the validation errors of
+// the API.
+var userAttrs = []string{"first_name", "last_name", "is_disabled", "locale", "home_folder_id", "models_dir_validated"}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
-	user, userErr := api.CreateUser(
-		sdk.WriteUser{
-			FirstName: conv.PString(d.Get("first_name").(string)),
-			LastName:  conv.PString(d.Get("last_name").(string)),
-		}, "", nil,
-	)
+	body := sdk.WriteUser{
+		FirstName:    conv.PString(d.Get("first_name").(string)),
+		LastName:     conv.PString(d.Get("last_name").(string)),
+		Locale:       conv.PString(d.Get("locale").(string)),
+		HomeFolderId: conv.PString(d.Get("home_folder_id").(string)),
+	}
+	// the defaults of looker are not sent, so that users are created the same way as before these attributes were added
+	if d.Get("is_disabled").(bool) {
+		body.IsDisabled = conv.P(true)
+	}
+	if v, ok := d.GetOkExists("models_dir_validated"); ok { //nolint:staticcheck // GetOk cannot tell if models_dir_validated has been set to false
+		body.ModelsDirValidated = conv.P(v.(bool))
+	}
+
+	user, userErr := api.CreateUser(body, "", nil)
 	if userErr != nil {
-		return diag.FromErr(userErr)
+		return apiDiags(userErr, "failed to create user", userAttrs...)
 	}
 
 	if user.Id == nil {
@@ -68,19 +129,21 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, c interface
 		}, "", nil,
 	)
 	if credErr != nil {
-		return diag.FromErr(credErr)
+		return apiDiags(credErr, "failed to set the email of the user", "email")
 	}
 
-	_, sendEmailErr := api.SendUserCredentialsEmailPasswordReset(*user.Id, "", nil)
-	if sendEmailErr != nil {
-		return diag.FromErr(sendEmailErr)
+	if d.Get("send_password_reset").(bool) {
+		_, sendEmailErr := api.SendUserCredentialsEmailPasswordReset(*user.Id, "", nil)
+		if sendEmailErr != nil {
+			return apiDiags(sendEmailErr, "failed to send a password reset email to the user")
+		}
 	}
 
 	return resourceUserRead(ctx, d, c)
 }
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userID := d.Id()
 	user, userErr := api.User(userID, "", nil)
@@ -89,31 +152,51 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, c interface{}
 		return nil
 	}
 	if userErr != nil {
-		return diag.FromErr(userErr)
+		return apiDiags(userErr, "failed to read user")
 	}
 
 	result := multierror.Append(
 		d.Set("email", user.Email),
 		d.Set("first_name", user.FirstName),
 		d.Set("last_name", user.LastName),
+		d.Set("is_disabled", conv.Deref(user.IsDisabled)),
+		d.Set("locale", user.Locale),
+		d.Set("home_folder_id", user.HomeFolderId),
+		d.Set("models_dir_validated", conv.Deref(user.ModelsDirValidated)),
+		d.Set("credentials_email", user.CredentialsEmail != nil),
+		d.Set("credentials_saml", user.CredentialsSaml != nil),
 	)
 
 	return diag.FromErr(result.ErrorOrNil())
 }
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userID := d.Id()
-	if d.HasChanges("first_name", "last_name") {
-		_, updateErr := api.UpdateUser(userID,
-			sdk.WriteUser{
-				FirstName: conv.PString(d.Get("first_name").(string)),
-				LastName:  conv.PString(d.Get("last_name").(string)),
-			}, "", nil,
-		)
+	if d.HasChanges("first_name", "last_name", "is_disabled", "locale", "home_folder_id", "models_dir_validated") {
+		// only the attributes which have changed are sent
+		var body sdk.WriteUser
+		if d.HasChanges("first_name", "last_name") {
+			body.FirstName = conv.PString(d.Get("first_name").(string))
+			body.LastName = conv.PString(d.Get("last_name").(string))
+		}
+		if d.HasChange("is_disabled") {
+			body.IsDisabled = conv.P(d.Get("is_disabled").(bool))
+		}
+		if d.HasChange("locale") {
+			body.Locale = conv.PString(d.Get("locale").(string))
+		}
+		if d.HasChange("home_folder_id") {
+			body.HomeFolderId = conv.PString(d.Get("home_folder_id").(string))
+		}
+		if d.HasChange("models_dir_validated") {
+			body.ModelsDirValidated = conv.P(d.Get("models_dir_validated").(bool))
+		}
+
+		_, updateErr := api.UpdateUser(userID, body, "", nil)
 		if updateErr != nil {
-			return diag.FromErr(updateErr)
+			return apiDiags(updateErr, "failed to update user", userAttrs...)
 		}
 	}
 
@@ -124,12 +207,14 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, c interface
 			}, "", nil,
 		)
 		if updateCredsErr != nil {
-			return diag.FromErr(updateCredsErr)
+			return apiDiags(updateCredsErr, "failed to update the email of the user", "email")
 		}
 
-		_, sendEmailErr := api.SendUserCredentialsEmailPasswordReset(d.Id(), "", nil)
-		if sendEmailErr != nil {
-			return diag.FromErr(sendEmailErr)
+		if d.Get("send_password_reset").(bool) {
+			_, sendEmailErr := api.SendUserCredentialsEmailPasswordReset(d.Id(), "", nil)
+			if sendEmailErr != nil {
+				return apiDiags(sendEmailErr, "failed to send a password reset email to the user")
+			}
 		}
 	}
 
@@ -137,12 +222,29 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, c interface
 }
 
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
-
-	_, delErr := api.DeleteUser(d.Id(), nil)
-	if !errors.Is(delErr, sdk.ErrNotFound) {
-		diag.FromErr(delErr)
+	api := c.(*lookerClient).LookerSDK
+
+	var delErr error
+	switch d.Get("deletion_mode").(string) {
+	case "disable":
+		// disabling the user keeps the content they own, eg. for offboarding
+		_, delErr = api.UpdateUser(d.Id(), sdk.WriteUser{IsDisabled: conv.P(true)}, "", nil)
+	default:
+		_, delErr = api.DeleteUser(d.Id(), nil)
+	}
+	if delErr != nil && !errors.Is(delErr, sdk.ErrNotFound) {
+		return apiDiags(delErr, "failed to delete user")
 	}
 
 	return nil
 }
+
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, c interface{}) ([]*schema.ResourceData, error) {
+	// the attributes which are not read from looker are set to their defaults, so that an imported user has no changes to apply
+	result := multierror.Append(
+		d.Set("send_password_reset", true),
+		d.Set("deletion_mode", "delete"),
+	)
+
+	return []*schema.ResourceData{d}, result.ErrorOrNil()
+}
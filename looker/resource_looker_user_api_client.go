@@ -40,7 +40,7 @@ func resourceUserAPIClient() *schema.Resource {
 }
 
 func resourceUserAPIClientCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	res, err := api.CreateUserCredentialsApi3(d.Get("user_id").(string), "", nil)
 	if err != nil {
@@ -70,7 +70,7 @@ func resourceUserAPIClientCreate(ctx context.Context, d *schema.ResourceData, c
 }
 
 func resourceUserAPIClientRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, err := api.UserCredentialsApi3(d.Get("user_id").(string), d.Id(), "", nil)
 	if errors.Is(err, sdk.ErrNotFound) {
@@ -82,7 +82,7 @@ func resourceUserAPIClientRead(ctx context.Context, d *schema.ResourceData, c in
 }
 
 func resourceUserAPIClientDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, err := api.DeleteUserCredentialsApi3(d.Get("user_id").(string), d.Id(), nil)
 	if !errors.Is(err, sdk.ErrNotFound) {
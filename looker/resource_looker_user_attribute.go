@@ -79,7 +79,7 @@ func resourceUserAttribute() *schema.Resource {
 }
 
 func resourceUserAttributeCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userAttrs, err := buildUserAttributeInput(d)
 	if err != nil {
@@ -88,7 +88,7 @@ func resourceUserAttributeCreate(ctx context.Context, d *schema.ResourceData, c
 
 	userAttributes, err := api.CreateUserAttribute(*userAttrs, "id", nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiags(err, "failed to create user attribute", "name", "label", "default_value")
 	}
 
 	if userAttributes.Id == nil {
@@ -100,7 +100,7 @@ func resourceUserAttributeCreate(ctx context.Context, d *schema.ResourceData, c
 }
 
 func resourceUserAttributeRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userAttributes, err := api.UserAttribute(d.Id(), "", nil)
 	if errors.Is(err, sdk.ErrNotFound) {
@@ -152,7 +152,7 @@ func resourceUserAttributeRead(ctx context.Context, d *schema.ResourceData, c in
 }
 
 func resourceUserAttributeUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userAttrs, err := buildUserAttributeInput(d)
 	if err != nil {
@@ -161,14 +161,14 @@ func resourceUserAttributeUpdate(ctx context.Context, d *schema.ResourceData, c
 
 	_, err = api.UpdateUserAttribute(d.Id(), *userAttrs, "id", nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiDiags(err, "failed to update user attribute", "name", "label", "default_value")
 	}
 
 	return resourceUserAttributeRead(ctx, d, c)
 }
 
 func resourceUserAttributeDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	_, err := api.DeleteUserAttribute(d.Id(), nil)
 	if !errors.Is(err, sdk.ErrNotFound) {
@@ -65,7 +65,7 @@ func resourceUserAttributeGroups() *schema.Resource {
 }
 
 func resourceUserAttributeGroupsCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	groupValues := d.Get(groupValuesKey).(*schema.Set).List()
 
@@ -93,7 +93,7 @@ func resourceUserAttributeGroupsCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceUserAttributeGroupsRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userAttrGroups, err := api.AllUserAttributeGroupValues(
 		d.Id(),
@@ -123,7 +123,7 @@ func resourceUserAttributeGroupsRead(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceUserAttributeGroupsUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	groupValues := d.Get(groupValuesKey).(*schema.Set).List()
 	for _, groupValue := range groupValues {
@@ -153,7 +153,7 @@ func resourceUserAttributeGroupsUpdate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceUserAttributeGroupsDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	groupValues := d.Get(groupValuesKey).(*schema.Set).List()
 	for _, groupValue := range groupValues {
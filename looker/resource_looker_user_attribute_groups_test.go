@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func init() {
@@ -85,7 +84,7 @@ func testAccUserAttributeGroup(userAttrGroupResource, groupResource, expectedVal
 			return errors.New("group ID is not set")
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		// id of user attribute is in form <user_attribute_id>_<group_id>_<...>
 		userAttrs, err := client.AllUserAttributeGroupValues(userAttrGroupRes.Primary.ID, "", nil)
@@ -53,7 +53,7 @@ func resourceUserAttributeUser() *schema.Resource {
 }
 
 func resourceUserAttributeUserCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userAttrID := d.Get("user_attribute_id").(string)
 	userID := d.Get("user_id").(string)
@@ -73,7 +73,7 @@ func resourceUserAttributeUserCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceUserAttributeUserRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userID := d.Get("user_id").(string)
 
@@ -122,7 +122,7 @@ func resourceUserAttributeUserRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceUserAttributeUserDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	userAttrID := d.Get("user_attribute_id").(string)
 	userID := d.Get("user_id").(string)
@@ -137,12 +137,12 @@ func resourceUserAttributeUserDelete(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceUserAttributeUserImport(ctx context.Context, d *schema.ResourceData, c interface{}) ([]*schema.ResourceData, error) {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
 
 	// id is <user_attribute_id>_<user_id>
 	s := strings.Split(d.Id(), "_")
 	if len(s) < 2 {
-		diag.Errorf("invalid id, should be of the form <parent_group_id>_<group_id>")
+		return nil, fmt.Errorf("invalid id %q, should be of the form <user_attribute_id>_<user_id>", d.Id())
 	}
 
 	userAttributes, err := api.UserAttribute(s[0], "", nil)
@@ -83,7 +83,7 @@ func testAccRoleUserAttributeUser(userResource, userAttrUserResource, expectedVa
 			return errors.New("user attribute ID is not set")
 		}
 
-		client := testAccProvider.Meta().(*sdk.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 
 		// UserAttributeIds is broken, cannot filter for a specific user attribute
 		userAttrs, err := client.UserAttributeUserValues(sdk.RequestUserAttributeUserValues{
@@ -16,7 +16,7 @@ import (
 
 func resourceUserRoles() *schema.Resource {
 	return &schema.Resource{
-		Description: "This resource binds a set of roles to a Looker user. This is an additive and non-authorative resource that grants roles in addition to current roles configured in Looker.",
+		Description: "This resource binds a set of roles to a Looker user. By default this is an additive and non-authorative resource that grants roles in addition to current roles configured in Looker. If `authoritative` is set, any role on the user that is not defined in this resource is removed.",
 
 		CreateContext: resourceUserRolesCreate,
 		ReadContext:   resourceUserRolesRead,
@@ -40,29 +40,53 @@ func resourceUserRoles() *schema.Resource {
 				Required:    true,
 				Description: "A slice of role_ids which will be assigned to the user",
 			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, any role on the user that is not defined in this resource is removed. If false, roles are granted in addition to the roles already configured in Looker",
+			},
 		},
 	}
 }
 
-// resourceUserRolesCreate reads what exists in looker and appends the new roles to the existing roles
+// resourceUserRolesCreate reads what exists in looker and appends the new roles to the existing roles. If the resource is authoritative,
+// the existing roles are replaced.
 func resourceUserRolesCreate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	// the roles of the user are read before they are set, so changes to the roles of the same user are serialised
+	lockKey := userRolesLockKey(d.Get("user_id").(string))
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
+
+	if d.Get("authoritative").(bool) {
+		userID := d.Get("user_id").(string)
+		if setErr := setAuthoritativeUserRoles(api, d, userID); setErr != nil {
+			return apiDiags(setErr, "failed to set the roles of user "+d.Get("user_id").(string), "role_ids")
+		}
+
+		d.SetId(userID)
+
+		return resourceUserRolesRead(ctx, d, c)
+	}
 
 	// get diff between roles in the resource data and in looker
-	diff, err := userRolesDiff(api, d)
-	if err != nil {
-		return diag.FromErr(err)
+	diff, diffErr := userRolesDiff(api, d)
+	if diffErr != nil {
+		return apiDiags(diffErr, "failed to read the roles of user "+d.Get("user_id").(string))
 	}
 
 	userID := d.Get("user_id").(string)
 	rscRoleIDs, rolesErr := getRolesByUser(api, userID)
 	if rolesErr != nil {
-		return diag.FromErr(rolesErr)
+		return apiDiags(rolesErr, "failed to read the roles of user "+userID)
 	}
 
 	_, setErr := api.SetUserRoles(userID, append(diff, rscRoleIDs...), "", nil)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("create set err: %s, userID: %s", setErr.Error(), userID))
+	if setErr != nil {
+		return apiDiags(setErr, "failed to set the roles of user "+userID, "role_ids")
 	}
 
 	d.SetId(userID)
@@ -70,18 +94,41 @@ func resourceUserRolesCreate(ctx context.Context, d *schema.ResourceData, c inte
 	return resourceUserRolesRead(ctx, d, c)
 }
 
-// resourceUserRolesRead reads what has been set in looker, and sets just what is provisioned in terraform to the state
+// resourceUserRolesRead reads what has been set in looker, and sets just what is provisioned in terraform to the state. If the resource is
+// authoritative all roles are set to the state, so that roles granted outside of terraform are reported as drift.
 func resourceUserRolesRead(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	if d.Get("authoritative").(bool) {
+		lookerRoleIDs, rolesErr := getRolesByUser(api, d.Id())
+		if errors.Is(rolesErr, sdk.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		if rolesErr != nil {
+			return apiDiags(rolesErr, "failed to read the roles of user "+d.Id())
+		}
 
+		result := multierror.Append(
+			d.Set("user_id", d.Id()),
+			d.Set("role_ids", lookerRoleIDs),
+		)
+		return diag.FromErr(result.ErrorOrNil())
+	}
+
+	// the roles of a user who has been deleted cannot be read, so the resource is removed from the state
 	diff, diffErr := userRolesDiff(api, d)
+	if errors.Is(diffErr, sdk.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
 	if diffErr != nil {
-		return diag.FromErr(diffErr)
+		return apiDiags(diffErr, "failed to read the roles of user "+d.Id())
 	}
 
 	rscRoleIDs, rolesErr := getRolesByUser(api, d.Id())
 	if rolesErr != nil {
-		return diag.FromErr(rolesErr)
+		return apiDiags(rolesErr, "failed to read the roles of user "+d.Id())
 	}
 
 	result := multierror.Append(
@@ -93,7 +140,21 @@ func resourceUserRolesRead(ctx context.Context, d *schema.ResourceData, c interf
 
 // resourceUserRolesUpdate inspects the changes between the old and new state, and appends these changes to existing roles in looker for the given user_id.
 func resourceUserRolesUpdate(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	// the roles of the user are read before they are set, so changes to the roles of the same user are serialised
+	lockKey := userRolesLockKey(d.Get("user_id").(string))
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
+
+	if d.Get("authoritative").(bool) {
+		if setErr := setAuthoritativeUserRoles(api, d, d.Get("user_id").(string)); setErr != nil {
+			return apiDiags(setErr, "failed to set the roles of user "+d.Get("user_id").(string), "role_ids")
+		}
+
+		return resourceUserRolesRead(ctx, d, c)
+	}
 
 	/*
 		compare old and new state, and update the roles accordingly, preserving any configuration in looker only. eg.
@@ -127,7 +188,7 @@ func resourceUserRolesUpdate(ctx context.Context, d *schema.ResourceData, c inte
 	userID := d.Get("user_id").(string)
 	lookerRoles, rolesErr := getRolesByUser(api, userID)
 	if rolesErr != nil {
-		return diag.FromErr(rolesErr)
+		return apiDiags(rolesErr, "failed to read the roles of user "+userID)
 	}
 
 	// diff between what was has changed in the state and what is in looker
@@ -135,23 +196,62 @@ func resourceUserRolesUpdate(ctx context.Context, d *schema.ResourceData, c inte
 
 	_, setErr := api.SetUserRoles(userID, append(diff, newIDs...), "", nil)
 	if setErr != nil {
-		return diag.Errorf(setErr.Error())
+		return apiDiags(setErr, "failed to set the roles of user "+userID, "role_ids")
 	}
 
 	return resourceUserRolesRead(ctx, d, c)
 }
 
 func resourceUserRolesDelete(ctx context.Context, d *schema.ResourceData, c interface{}) diag.Diagnostics {
-	api := c.(*sdk.LookerSDK)
+	api := c.(*lookerClient).LookerSDK
+
+	// the roles of the user are read before they are set, so changes to the roles of the same user are serialised
+	lockKey := userRolesLockKey(d.Id())
+	locks := c.(*lookerClient).locks
+	locks.Lock(lockKey)
+	defer locks.Unlock(lockKey)
+
+	// an authoritative resource owns all roles on the user, so all roles are removed
+	if d.Get("authoritative").(bool) {
+		// the roles of a user who has been deleted are already removed
+		_, setErr := api.SetUserRoles(d.Id(), []string{}, "", nil)
+		if setErr != nil && !errors.Is(setErr, sdk.ErrNotFound) {
+			return apiDiags(setErr, "failed to remove the roles of user "+d.Id())
+		}
+
+		return nil
+	}
 
 	diff, diffErr := userRolesDiff(api, d)
+	if errors.Is(diffErr, sdk.ErrNotFound) {
+		return nil
+	}
 	if diffErr != nil {
-		return diag.FromErr(diffErr)
+		return apiDiags(diffErr, "failed to read the roles of user "+d.Id())
 	}
 
 	_, setErr := api.SetUserRoles(d.Id(), diff, "", nil)
+	if setErr != nil && !errors.Is(setErr, sdk.ErrNotFound) {
+		return apiDiags(setErr, "failed to remove the roles of user "+d.Id())
+	}
+
+	return nil
+}
+
+// setAuthoritativeUserRoles replaces all roles on the user with the roles in the resource data.
+func setAuthoritativeUserRoles(api *sdk.LookerSDK, d *schema.ResourceData, userID string) error {
+	rIDs, ok := d.Get("role_ids").(*schema.Set)
+	if !ok {
+		return errors.New("role_ids is not of type *schema.Set")
+	}
+	roleIDs, rErr := conv.SchemaSetToSliceString(rIDs)
+	if rErr != nil {
+		return rErr
+	}
+
+	_, setErr := api.SetUserRoles(userID, roleIDs, "", nil)
 	if setErr != nil {
-		return diag.Errorf("err: %s, userID: %s", setErr.Error(), d.Id())
+		return fmt.Errorf("failed to set the roles of user %s: %w", userID, setErr)
 	}
 
 	return nil
@@ -179,6 +279,11 @@ func userRolesDiff(api *sdk.LookerSDK, d *schema.ResourceData) ([]string, error)
 	return slice.Diff(rscRoleIDs, lookerRoleIDs), nil
 }
 
+// userRolesLockKey returns the key of the lock that guards the roles of the user.
+func userRolesLockKey(userID string) string {
+	return "user_roles/" + userID
+}
+
 // getRolesByUser takes a client and a userID and returns a slice the roles allocated to a user with the given userID.
 func getRolesByUser(api *sdk.LookerSDK, userID string) ([]string, error) {
 	ur, urErr := api.UserRoles(sdk.RequestUserRoles{
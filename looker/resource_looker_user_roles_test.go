@@ -0,0 +1,101 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+
+	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
+	"github.com/resolutionlife/terraform-provider-looker/internal/slice"
+)
+
+func TestAccLookerUserRolesAuthoritative(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_user_roles_authoritative")
+	defer stop() //nolint:errcheck
+
+	// user 27 has role 3 before the test, which is replaced by the roles of the resource
+	config := `
+	resource "looker_user_roles" "test_acc" {
+		user_id       = "27"
+		role_ids      = ["2", "5"]
+		authoritative = true
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserRolesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user_roles.test_acc", "role_ids.#", "2"),
+					testAccUserRoles("27", []string{"2", "5"}),
+				),
+			},
+			{
+				// grant role 3 to the user outside of terraform, which is reported as drift
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*lookerClient)
+
+					if _, err := client.SetUserRoles("27", []string{"2", "3", "5"}, "", nil); err != nil {
+						t.Fatalf("failed to set the roles of user 27: %v", err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// the role granted outside of terraform is removed on apply
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user_roles.test_acc", "role_ids.#", "2"),
+					testAccUserRoles("27", []string{"2", "5"}),
+				),
+			},
+		},
+	})
+}
+
+// testAccUserRoles checks that the user has exactly the expected roles in Looker.
+func testAccUserRoles(userID string, expectedRoleIDs []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*lookerClient)
+
+		roleIDs, err := getRolesByUser(client.LookerSDK, userID)
+		if err != nil {
+			return fmt.Errorf("failed to read the roles of user %s: %w", userID, err)
+		}
+
+		if !slice.UnorderedEqual(roleIDs, expectedRoleIDs) {
+			return fmt.Errorf("roles of user do not match expected: %v actual: %v", expectedRoleIDs, roleIDs)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckUserRolesDestroy checks that all roles are removed from the users of authoritative resources.
+func testAccCheckUserRolesDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "looker_user_roles" || r.Primary.Attributes["authoritative"] != "true" {
+			continue
+		}
+
+		roles, err := client.UserRoles(sdk.RequestUserRoles{UserId: r.Primary.ID, DirectAssociationOnly: conv.PBool(true)}, nil)
+		if err != nil {
+			return err
+		}
+		if len(roles) != 0 {
+			return fmt.Errorf("user %s still has %d roles", r.Primary.ID, len(roles))
+		}
+	}
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package looker
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	v4 "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 	"github.com/resolutionlife/terraform-provider-looker/internal/conv"
 )
@@ -73,3 +75,63 @@ func TestAccLookerUser(t *testing.T) {
 		},
 	})
 }
+
+func TestAccLookerUserLifecycle(t *testing.T) {
+	stop := NewTestProvider("../fixture/looker_user_lifecycle")
+	defer stop() //nolint:errcheck
+
+	config := func(disabled bool) string {
+		return fmt.Sprintf(`
+		  resource "looker_user" "test_acc" {
+		    email      = "test-acc-sso@email.com"
+		    first_name = "Sam"
+		    last_name  = "Sso"
+		    locale     = "fr_FR"
+
+		    is_disabled         = %t
+		    send_password_reset = false
+		    deletion_mode       = "disable"
+		  }
+		`, disabled)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserDisabled("940"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user.test_acc", "locale", "fr_FR"),
+					resource.TestCheckResourceAttr("looker_user.test_acc", "home_folder_id", "1"),
+					resource.TestCheckResourceAttr("looker_user.test_acc", "is_disabled", "false"),
+					resource.TestCheckResourceAttr("looker_user.test_acc", "credentials_email", "true"),
+					resource.TestCheckResourceAttr("looker_user.test_acc", "credentials_saml", "false"),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user.test_acc", "is_disabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckUserDisabled checks that the user has been disabled, rather than deleted.
+func testAccCheckUserDisabled(id string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*lookerClient)
+
+		user, err := client.User(id, "", nil)
+		if err != nil {
+			return err
+		}
+		if user.IsDisabled == nil || !*user.IsDisabled {
+			return fmt.Errorf("expected user %s to be disabled", id)
+		}
+
+		return nil
+	}
+}
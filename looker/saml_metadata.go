@@ -0,0 +1,286 @@
+package looker
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// idpCertExpiryWarningDays is the number of days before the IdP certificate expires from which the provider warns about the expiry.
+const idpCertExpiryWarningDays = 30
+
+// samlRedirectBinding is the SAML binding Looker uses to send authentication requests to the IdP.
+const samlRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+
+// idpMetadata is the result of parsing the SAML metadata of an IdP, matching sdk.SamlMetadataParseResult.
+type idpMetadata struct {
+	Issuer string
+	URL    string
+	Cert   string
+}
+
+type samlEntitiesDescriptor struct {
+	EntityDescriptors []samlEntityDescriptor `xml:"EntityDescriptor"`
+}
+
+type samlEntityDescriptor struct {
+	EntityID         string                `xml:"entityID,attr"`
+	IDPSSODescriptor *samlIDPSSODescriptor `xml:"IDPSSODescriptor"`
+}
+
+type samlIDPSSODescriptor struct {
+	KeyDescriptors []struct {
+		Use         string `xml:"use,attr"`
+		Certificate string `xml:"KeyInfo>X509Data>X509Certificate"`
+	} `xml:"KeyDescriptor"`
+	SingleSignOnServices []struct {
+		Binding  string `xml:"Binding,attr"`
+		Location string `xml:"Location,attr"`
+	} `xml:"SingleSignOnService"`
+}
+
+// parseIdpMetadata parses the SAML metadata XML of an IdP, which is either an EntityDescriptor or an EntitiesDescriptor. The
+// first entity with an IDPSSODescriptor is used.
+func parseIdpMetadata(metadata string) (idpMetadata, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal([]byte(metadata), &root); err != nil {
+		return idpMetadata{}, fmt.Errorf("failed to parse IdP metadata: %w", err)
+	}
+
+	var entities []samlEntityDescriptor
+	switch root.XMLName.Local {
+	case "EntityDescriptor":
+		var entity samlEntityDescriptor
+		if err := xml.Unmarshal([]byte(metadata), &entity); err != nil {
+			return idpMetadata{}, fmt.Errorf("failed to parse IdP metadata: %w", err)
+		}
+		entities = append(entities, entity)
+	case "EntitiesDescriptor":
+		var descriptor samlEntitiesDescriptor
+		if err := xml.Unmarshal([]byte(metadata), &descriptor); err != nil {
+			return idpMetadata{}, fmt.Errorf("failed to parse IdP metadata: %w", err)
+		}
+		entities = descriptor.EntityDescriptors
+	default:
+		return idpMetadata{}, fmt.Errorf("failed to parse IdP metadata: expected an EntityDescriptor or EntitiesDescriptor, got %s", root.XMLName.Local)
+	}
+
+	for _, entity := range entities {
+		if entity.IDPSSODescriptor == nil {
+			continue
+		}
+
+		md := idpMetadata{Issuer: entity.EntityID}
+
+		for _, sso := range entity.IDPSSODescriptor.SingleSignOnServices {
+			if md.URL == "" || sso.Binding == samlRedirectBinding {
+				md.URL = sso.Location
+			}
+		}
+		for _, key := range entity.IDPSSODescriptor.KeyDescriptors {
+			// a key descriptor without a use is used for both signing and encryption
+			if md.Cert == "" && (key.Use == "" || key.Use == "signing") {
+				md.Cert = strings.Join(strings.Fields(key.Certificate), "")
+			}
+		}
+
+		if md.URL == "" {
+			return idpMetadata{}, errors.New("failed to parse IdP metadata: the IDPSSODescriptor has no SingleSignOnService")
+		}
+		if md.Cert == "" {
+			return idpMetadata{}, errors.New("failed to parse IdP metadata: the IDPSSODescriptor has no signing certificate")
+		}
+
+		return md, nil
+	}
+
+	return idpMetadata{}, errors.New("failed to parse IdP metadata: no entity has an IDPSSODescriptor")
+}
+
+// parseIdpCert parses the certificate of an IdP, which is either PEM encoded or the base64 encoded DER of the certificate, as
+// found in the X509Certificate element of the SAML metadata.
+func parseIdpCert(cert string) (*x509.Certificate, error) {
+	var der []byte
+	if strings.Contains(cert, "-----BEGIN") {
+		// the lines of the certificate are trimmed, as it is often indented in the config
+		lines := strings.Split(cert, "\n")
+		for i := range lines {
+			lines[i] = strings.TrimSpace(lines[i])
+		}
+
+		block, _ := pem.Decode([]byte(strings.Join(lines, "\n")))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, errors.New("failed to parse IdP certificate: expected a PEM encoded CERTIFICATE")
+		}
+		der = block.Bytes
+	} else {
+		var err error
+		der, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(cert), ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse IdP certificate: %w", err)
+		}
+	}
+
+	x509Cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse IdP certificate: %w", err)
+	}
+
+	return x509Cert, nil
+}
+
+// checkIdpCert returns an error if the certificate is not valid at now, and a warning if it expires within
+// idpCertExpiryWarningDays of now.
+func checkIdpCert(cert *x509.Certificate, now time.Time, path cty.Path) diag.Diagnostics {
+	switch {
+	case now.After(cert.NotAfter):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "IdP certificate has expired",
+			Detail:        fmt.Sprintf("The IdP certificate %s expired at %s, so SAML logins will fail.", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339)),
+			AttributePath: path,
+		}}
+	case now.Before(cert.NotBefore):
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "IdP certificate is not valid yet",
+			Detail:        fmt.Sprintf("The IdP certificate %s is not valid until %s, so SAML logins will fail.", cert.Subject, cert.NotBefore.UTC().Format(time.RFC3339)),
+			AttributePath: path,
+		}}
+	case now.AddDate(0, 0, idpCertExpiryWarningDays).After(cert.NotAfter):
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "IdP certificate expires soon",
+			Detail:        fmt.Sprintf("The IdP certificate %s expires at %s. SAML logins will fail after it expires, unless it is replaced.", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339)),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+// validateIdpCert is the ValidateDiagFunc of IdP certificates, which errors on certificates that cannot be parsed. The validity of
+// the certificate depends on the time of the plan, so it is checked by idpCertValidityError and idpCertWarnings instead.
+func validateIdpCert(i interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := parseIdpCert(i.(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid IdP certificate",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+// idpCertValidityError returns an error if the IdP certificate is not valid at now. A certificate which cannot be parsed is not an
+// error, as it is reported by validateIdpCert.
+func idpCertValidityError(cert string, now time.Time) error {
+	x509Cert, err := parseIdpCert(cert)
+	if err != nil {
+		return nil
+	}
+
+	for _, d := range checkIdpCert(x509Cert, now, nil) {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	return nil
+}
+
+// idpCertWarnings returns the diagnostics of checkIdpCert as warnings, so that a certificate in the state which has expired since it
+// was applied is reported without failing plans which do not change it.
+func idpCertWarnings(cert string, now time.Time, path cty.Path) diag.Diagnostics {
+	x509Cert, err := parseIdpCert(cert)
+	if err != nil {
+		return nil
+	}
+
+	diags := checkIdpCert(x509Cert, now, path)
+	for i := range diags {
+		diags[i].Severity = diag.Warning
+	}
+
+	return diags
+}
+
+// idpCertSchema returns the computed attributes which describe an IdP certificate.
+func idpCertSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"idp_cert_fingerprint": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The SHA-256 fingerprint of the IdP certificate, as colon separated hex",
+		},
+		"idp_cert_subject": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The subject of the IdP certificate",
+		},
+		"idp_cert_not_after": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time the IdP certificate expires, in RFC 3339 format",
+		},
+		"idp_cert_signature_algorithm": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The algorithm the IdP certificate is signed with, eg. `SHA256-RSA`",
+		},
+	}
+}
+
+// flattenIdpCert returns the values of the attributes of idpCertSchema. All values are empty if the certificate cannot be
+// parsed.
+func flattenIdpCert(cert string) map[string]string {
+	attrs := map[string]string{
+		"idp_cert_fingerprint":         "",
+		"idp_cert_subject":             "",
+		"idp_cert_not_after":           "",
+		"idp_cert_signature_algorithm": "",
+	}
+
+	x509Cert, err := parseIdpCert(cert)
+	if err != nil {
+		return attrs
+	}
+
+	fingerprint := sha256.Sum256(x509Cert.Raw)
+	hex := make([]string, len(fingerprint))
+	for i, b := range fingerprint {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+
+	attrs["idp_cert_fingerprint"] = strings.Join(hex, ":")
+	attrs["idp_cert_subject"] = x509Cert.Subject.String()
+	attrs["idp_cert_not_after"] = x509Cert.NotAfter.UTC().Format(time.RFC3339)
+	attrs["idp_cert_signature_algorithm"] = x509Cert.SignatureAlgorithm.String()
+
+	return attrs
+}
+
+// setIdpCert sets the attributes of idpCertSchema from the IdP certificate.
+func setIdpCert(d *schema.ResourceData, cert string) error {
+	var result *multierror.Error
+	for k, v := range flattenIdpCert(cert) {
+		result = multierror.Append(result, d.Set(k, v))
+	}
+
+	return result.ErrorOrNil()
+}
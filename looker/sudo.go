@@ -0,0 +1,146 @@
+package looker
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+// tokenExpiryMargin is how long before it expires a cached access token is replaced, so a token does not expire while it is in use.
+const tokenExpiryMargin = time.Minute
+
+// tokenCache holds the access tokens of the users the provider runs as. Tokens are created with the login_user endpoint of the
+// Looker API, which requires the provider to be authenticated as an admin. A token is created once per user and reused for the
+// whole run, until it is about to expire.
+type tokenCache struct {
+	admin *sdk.LookerSDK
+
+	// users serializes the logins of each user, so that a user logs in once when several requests need a token at the same time,
+	// without blocking the requests of other users. mu only guards tokens.
+	users  *mutexKV
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+type cachedToken struct {
+	token  string
+	expiry time.Time
+}
+
+func newTokenCache(admin *sdk.LookerSDK) *tokenCache {
+	return &tokenCache{
+		admin:  admin,
+		users:  newMutexKV(),
+		tokens: make(map[string]cachedToken),
+	}
+}
+
+// get returns an access token of the user, creating a new token if there is no valid token in the cache.
+func (c *tokenCache) get(userID string) (string, error) {
+	c.users.Lock(userID)
+	defer c.users.Unlock(userID)
+
+	if t, ok := c.cached(userID); ok {
+		return t, nil
+	}
+
+	// the token is not associative, so that content created with the token is owned by, and attributed to, the user
+	token, loginErr := c.admin.LoginUser(userID, false, nil)
+	if loginErr != nil {
+		return "", fmt.Errorf("failed to login as user %s: %w", userID, loginErr)
+	}
+	if token.AccessToken == nil {
+		return "", fmt.Errorf("login as user %s did not return an access token", userID)
+	}
+
+	expiry := time.Now().Add(time.Hour)
+	if token.ExpiresIn != nil {
+		expiry = time.Now().Add(time.Duration(*token.ExpiresIn) * time.Second)
+	}
+	c.mu.Lock()
+	c.tokens[userID] = cachedToken{token: *token.AccessToken, expiry: expiry}
+	c.mu.Unlock()
+
+	return *token.AccessToken, nil
+}
+
+// cached returns the token of the user in the cache, if it is not about to expire.
+func (c *tokenCache) cached(userID string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, ok := c.tokens[userID]
+	if !ok || !time.Now().Add(tokenExpiryMargin).Before(t.expiry) {
+		return "", false
+	}
+
+	return t.token, true
+}
+
+// sudoTransport is a http.RoundTripper that authenticates requests as a user, with an access token from the token cache.
+type sudoTransport struct {
+	base   http.RoundTripper
+	userID string
+	tokens *tokenCache
+}
+
+func (t *sudoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, tokenErr := t.tokens.get(t.userID)
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+
+	// a RoundTripper must not modify the request, so the header is set on a copy
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "token "+token)
+
+	return t.base.RoundTrip(r)
+}
+
+// newSudoSDK returns a Looker SDK which makes all requests as the user. Requests are sent with the given transport, so they are
+// retried and rate limited in the same way as requests made by the provider.
+func newSudoSDK(settings rtl.ApiSettings, transport http.RoundTripper, tokens *tokenCache, userID string) *sdk.LookerSDK {
+	// the session must not authenticate with the client credentials of the provider
+	settings.ClientId = ""
+	settings.ClientSecret = ""
+
+	return sdk.NewLookerSDK(&rtl.AuthSession{
+		Config: settings,
+		Client: http.Client{
+			Transport: &sudoTransport{
+				base:   transport,
+				userID: userID,
+				tokens: tokens,
+			},
+			Timeout: time.Duration(settings.Timeout) * time.Second,
+		},
+	})
+}
+
+// runAsSchema is the schema of the run_as_user_id attribute of resources which can be managed as a specific user.
+func runAsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The id of the user to manage this resource as, eg. to create content owned by the user. The provider logs in as the user with the `login_user` endpoint of the Looker API, so the provider must be authenticated as an admin. Overrides the `run_as_user_id` of the provider",
+	}
+}
+
+// resourceAPI returns the Looker SDK to manage the resource with. If run_as_user_id is set on the resource, requests are made as that
+// user, otherwise the SDK of the provider is used.
+func resourceAPI(d *schema.ResourceData, c interface{}) *sdk.LookerSDK {
+	client := c.(*lookerClient)
+
+	userID, ok := d.GetOk("run_as_user_id")
+	if !ok {
+		return client.LookerSDK
+	}
+
+	return newSudoSDK(client.settings, client.transport, client.tokens, userID.(string))
+}
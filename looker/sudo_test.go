@@ -0,0 +1,190 @@
+package looker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	sdk "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+// newSudoServer returns a fake Looker API which creates access tokens for users with the login_user endpoint, and checks that the
+// roles of user 42 are only read with an access token of user 42. The number of calls to login_user is counted in logins.
+func newSudoServer(t *testing.T, logins *int32, expiresIn int) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/4.0/login":
+			fmt.Fprint(w, `{"access_token":"admin-token","expires_in":3600,"token_type":"Bearer"}`)
+		case "/api/4.0/login/42":
+			n := atomic.AddInt32(logins, 1)
+			if r.URL.Query().Get("associative") != "false" {
+				t.Errorf("expected a non associative token, got associative=%s", r.URL.Query().Get("associative"))
+			}
+			fmt.Fprintf(w, `{"access_token":"user-42-token-%d","expires_in":%d,"token_type":"Bearer"}`, n, expiresIn)
+		case "/api/4.0/users/42/roles":
+			if auth := r.Header.Get("Authorization"); auth != fmt.Sprintf("token user-42-token-%d", atomic.LoadInt32(logins)) {
+				t.Errorf("expected request to be authenticated as user 42, got authorization %q", auth)
+			}
+			fmt.Fprint(w, `[{"id":"1"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestSudoSDK(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiresIn  int
+		wantLogins int32
+	}{
+		{
+			name:       "token is cached",
+			expiresIn:  3600,
+			wantLogins: 1,
+		},
+		{
+			name:       "token is replaced before it expires",
+			expiresIn:  30,
+			wantLogins: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logins int32
+			srv := newSudoServer(t, &logins, tt.expiresIn)
+
+			settings := rtl.ApiSettings{
+				BaseUrl:      srv.URL,
+				ApiVersion:   "4.0",
+				ClientId:     "client-id",
+				ClientSecret: "client-secret",
+			}
+			tokens := newTokenCache(sdk.NewLookerSDK(rtl.NewAuthSessionWithTransport(settings, http.DefaultTransport)))
+
+			// SDKs for the same user share the token cache
+			for i := 0; i < 3; i++ {
+				api := newSudoSDK(settings, http.DefaultTransport, tokens, "42")
+				if _, err := api.UserRoles(sdk.RequestUserRoles{UserId: "42"}, nil); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if got := atomic.LoadInt32(&logins); got != tt.wantLogins {
+				t.Errorf("expected %d logins, got %d", tt.wantLogins, got)
+			}
+		})
+	}
+}
+
+func TestSudoSDKLoginError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/4.0/login" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"admin-token","expires_in":3600,"token_type":"Bearer"}`)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(srv.Close)
+
+	settings := rtl.ApiSettings{
+		BaseUrl:      srv.URL,
+		ApiVersion:   "4.0",
+		ClientId:     "client-id",
+		ClientSecret: "client-secret",
+	}
+	tokens := newTokenCache(sdk.NewLookerSDK(rtl.NewAuthSessionWithTransport(settings, http.DefaultTransport)))
+
+	api := newSudoSDK(settings, http.DefaultTransport, tokens, "42")
+	if _, err := api.UserRoles(sdk.RequestUserRoles{UserId: "42"}, nil); err == nil {
+		t.Error("expected an error when the provider cannot login as the user")
+	}
+}
+
+func TestTokenCacheLocksPerUser(t *testing.T) {
+	var logins int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/4.0/login":
+			fmt.Fprint(w, `{"access_token":"admin-token","expires_in":3600,"token_type":"Bearer"}`)
+		case "/api/4.0/login/7":
+			// the login of user 7 is slow
+			<-release
+			fmt.Fprint(w, `{"access_token":"user-7-token","expires_in":3600,"token_type":"Bearer"}`)
+		case "/api/4.0/login/42":
+			atomic.AddInt32(&logins, 1)
+			fmt.Fprint(w, `{"access_token":"user-42-token","expires_in":3600,"token_type":"Bearer"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	// the slow login is released before the server is closed, so that a failed test does not hang
+	var once sync.Once
+	unblock := func() { once.Do(func() { close(release) }) }
+	t.Cleanup(unblock)
+
+	settings := rtl.ApiSettings{
+		BaseUrl:      srv.URL,
+		ApiVersion:   "4.0",
+		ClientId:     "client-id",
+		ClientSecret: "client-secret",
+	}
+	tokens := newTokenCache(sdk.NewLookerSDK(rtl.NewAuthSessionWithTransport(settings, http.DefaultTransport)))
+
+	slow := make(chan error, 1)
+	go func() {
+		_, err := tokens.get("7")
+		slow <- err
+	}()
+
+	// the tokens of user 42 are created while user 7 is logging in, and user 42 logs in once for concurrent requests
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if token, err := tokens.get("42"); err != nil || token != "user-42-token" {
+					t.Errorf("expected the token of user 42, got %q: %v", token, err)
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the login of user 42 was blocked by the login of user 7")
+	}
+	if got := atomic.LoadInt32(&logins); got != 1 {
+		t.Errorf("expected user 42 to login once, got %d logins", got)
+	}
+
+	unblock()
+	if err := <-slow; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package looker
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryTransport is a http.RoundTripper that retries requests which fail with a 429 response, or idempotent requests which fail
+// with a 5xx response, waiting between attempts using exponential backoff. If the response has a Retry-After header, the wait time requested by the server is used instead.
+type retryTransport struct {
+	// logCtx is the context of the provider, which holds the terraform logger. The context of a request made by the Looker SDK
+	// does not, so it is only used for cancellation.
+	logCtx     context.Context
+	base       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(logCtx context.Context, base http.RoundTripper, maxRetries int, minWait, maxWait time.Duration) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+
+	return &retryTransport{
+		logCtx:     logCtx,
+		base:       base,
+		maxRetries: maxRetries,
+		minWait:    minWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		// the body of the request is consumed by each attempt, so it is rewound before retrying. A RoundTripper must not modify the
+		// request, so the rewound body is set on a copy
+		r := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if err != nil || !retryable(req.Method, resp.StatusCode) || attempt >= t.maxRetries {
+			return resp, err
+		}
+		// a request with a body can only be retried if the body can be read again
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := t.backoff(attempt, resp)
+		tflog.Warn(t.logCtx, "retrying looker API request", map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"status":  resp.StatusCode,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+
+		// the response body is drained so that the connection can be reused
+		drainBody(resp)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the time to wait before the next attempt. The wait doubles with each attempt, starting at minWait, and is capped at maxWait.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return minDuration(wait, t.maxWait)
+	}
+
+	wait := float64(t.minWait) * math.Pow(2, float64(attempt))
+	if wait > float64(t.maxWait) {
+		return t.maxWait
+	}
+
+	return time.Duration(wait)
+}
+
+// retryAfter parses the Retry-After header of the response, which is either a number of seconds or a HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// retryable returns true for responses where the request can be sent again, ie. when the API is rate limiting requests, or is
+// temporarily unavailable and the request is idempotent. A non-idempotent request, eg. a POST which creates a user, may have been
+// processed before the API failed, so it is not retried on a 5xx to avoid creating duplicates. 501 Not Implemented is never retried.
+func retryable(method string, code int) bool {
+	if code == http.StatusTooManyRequests {
+		return true
+	}
+	if code < 500 || code == http.StatusNotImplemented {
+		return false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func drainBody(resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096)) //nolint:errcheck
+	resp.Body.Close()
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// rateLimitTransport is a http.RoundTripper that limits the rate of requests, and the number of requests in flight at the same time.
+// A single rateLimitTransport is shared by all resources, so the limits apply to all requests made by the provider.
+type rateLimitTransport struct {
+	logCtx context.Context
+	base   http.RoundTripper
+
+	// interval is the minimum time between the start of two requests. An interval of 0 does not limit the rate of requests.
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+
+	// slots holds a value for each request in flight. A nil channel does not limit the number of concurrent requests.
+	slots chan struct{}
+}
+
+func newRateLimitTransport(logCtx context.Context, base http.RoundTripper, requestsPerSecond float64, maxConcurrent int) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t := &rateLimitTransport{
+		logCtx: logCtx,
+		base:   base,
+	}
+	if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if wait := t.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			t.release()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if waited := time.Since(start); waited > time.Millisecond {
+		tflog.Debug(t.logCtx, "looker API request was throttled", map[string]interface{}{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"wait":   waited.String(),
+		})
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+
+	// the request is in flight until the response body has been read, so the slot is released when the body is closed
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+// reserve reserves the next start time for a request, and returns how long the request must wait until it can be sent.
+func (t *rateLimitTransport) reserve() time.Duration {
+	if t.interval == 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+
+	return wait
+}
+
+func (t *rateLimitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+// releaseOnClose calls release once, when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
@@ -0,0 +1,298 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFailingServer returns a test server which responds with the given status codes in order, and then responds with 200 OK.
+// The number of requests received by the server is counted in calls.
+func newFailingServer(t *testing.T, calls *int32, headers http.Header, codes ...int) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+
+		body, _ := io.ReadAll(r.Body)
+		if int(n) <= len(codes) {
+			for k, v := range headers {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(codes[n-1])
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(body) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		codes      []int
+		maxRetries int
+		wantCode   int
+		wantCalls  int32
+	}{
+		{
+			name:       "success is not retried",
+			method:     http.MethodPost,
+			maxRetries: 3,
+			wantCode:   http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "429 and 5xx are retried",
+			method:     http.MethodPut,
+			codes:      []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable},
+			maxRetries: 3,
+			wantCode:   http.StatusOK,
+			wantCalls:  4,
+		},
+		{
+			name:       "last response is returned when retries are exhausted",
+			method:     http.MethodPut,
+			codes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusGatewayTimeout},
+			maxRetries: 2,
+			wantCode:   http.StatusGatewayTimeout,
+			wantCalls:  3,
+		},
+		{
+			name:       "retries are disabled",
+			method:     http.MethodPut,
+			codes:      []int{http.StatusTooManyRequests},
+			maxRetries: 0,
+			wantCode:   http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+		{
+			name:       "4xx is not retried",
+			method:     http.MethodPut,
+			codes:      []int{http.StatusNotFound},
+			maxRetries: 3,
+			wantCode:   http.StatusNotFound,
+			wantCalls:  1,
+		},
+		{
+			name:       "501 is not retried",
+			method:     http.MethodPut,
+			codes:      []int{http.StatusNotImplemented},
+			maxRetries: 3,
+			wantCode:   http.StatusNotImplemented,
+			wantCalls:  1,
+		},
+		{
+			name:       "429 is retried for POST",
+			method:     http.MethodPost,
+			codes:      []int{http.StatusTooManyRequests},
+			maxRetries: 3,
+			wantCode:   http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			// the object may have been created before the API failed
+			name:       "5xx is not retried for POST",
+			method:     http.MethodPost,
+			codes:      []int{http.StatusInternalServerError},
+			maxRetries: 3,
+			wantCode:   http.StatusInternalServerError,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := newFailingServer(t, &calls, nil, tt.codes...)
+
+			c := http.Client{Transport: newRetryTransport(context.Background(), nil, tt.maxRetries, time.Millisecond, 5*time.Millisecond)}
+			req, _ := http.NewRequest(tt.method, srv.URL, strings.NewReader(`{"name":"test"}`))
+			resp, err := c.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, resp.StatusCode)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, got)
+			}
+
+			// the request body must be sent again with each retry
+			if resp.StatusCode == http.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != `{"name":"test"}` {
+					t.Errorf("expected the request body to be resent, got %q", string(body))
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportDoesNotModifyRequest(t *testing.T) {
+	var calls int32
+	srv := newFailingServer(t, &calls, nil, http.StatusBadGateway)
+
+	req, _ := http.NewRequest(http.MethodPut, srv.URL, strings.NewReader(`{"name":"test"}`))
+	body := req.Body
+
+	resp, err := newRetryTransport(context.Background(), nil, 1, time.Millisecond, time.Millisecond).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+	if req.Body != body {
+		t.Error("expected the body of the request not to be replaced by the retry")
+	}
+}
+
+func TestRetryTransportRetryAfter(t *testing.T) {
+	var calls int32
+	srv := newFailingServer(t, &calls, http.Header{"Retry-After": []string{"1"}}, http.StatusTooManyRequests)
+
+	c := http.Client{Transport: newRetryTransport(context.Background(), nil, 1, time.Millisecond, 5*time.Second)}
+
+	start := time.Now()
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for the Retry-After duration, waited %s", elapsed)
+	}
+}
+
+func TestRetryTransportContextCancelled(t *testing.T) {
+	var calls int32
+	srv := newFailingServer(t, &calls, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	c := http.Client{Transport: newRetryTransport(context.Background(), nil, 3, time.Minute, time.Minute)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = c.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline exceeded, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	rt := newRetryTransport(context.Background(), nil, 10, time.Second, 10*time.Second)
+	resp := &http.Response{Header: http.Header{}}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for attempt, want := range expected {
+		if got := rt.backoff(attempt, resp); got != want {
+			t.Errorf("attempt %d: expected wait %s, got %s", attempt, want, got)
+		}
+	}
+
+	// the wait requested by the server is capped at the maximum wait
+	resp.Header.Set("Retry-After", "120")
+	if got := rt.backoff(0, resp); got != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped at %s, got %s", 10*time.Second, got)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if got := rt.backoff(0, resp); got != 0 {
+		t.Errorf("expected a Retry-After date in the past to not wait, got %s", got)
+	}
+}
+
+func TestRateLimitTransportConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if n <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := http.Client{Transport: newRateLimitTransport(context.Background(), nil, 0, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			resp, err := c.Get(srv.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func TestRateLimitTransportRate(t *testing.T) {
+	var calls int32
+	srv := newFailingServer(t, &calls, nil)
+
+	c := http.Client{Transport: newRateLimitTransport(context.Background(), nil, 20, 0)}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// the first request is sent immediately, and each following request waits 50ms
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected 5 requests at 20 requests per second to take at least 200ms, took %s", elapsed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 5 {
+		t.Errorf("expected 5 calls, got %d", got)
+	}
+}